@@ -192,3 +192,36 @@ func SetAttribute(o *js.Object, key string, value string) {
 func SetInnerHTML(o *js.Object, html string) {
 	o.Set("innerHTML", html)
 }
+
+// CreateTextNode creates a dom text node using the document html js.object
+func CreateTextNode(text string) *js.Object {
+	doc := GetDocument()
+	if doc == nil || doc == js.Undefined {
+		return nil
+	}
+	return doc.Call("createTextNode", text)
+}
+
+// ChildNodeAt returns the child node at the given index of the js object else nil
+func ChildNodeAt(o *js.Object, index int) *js.Object {
+	node := o.Get("childNodes").Call("item", index)
+	if node == nil || node == js.Undefined {
+		return nil
+	}
+	return node
+}
+
+// RemoveAttribute calls removeAttribute on the js object with the key
+func RemoveAttribute(o *js.Object, key string) {
+	o.Call("removeAttribute", key)
+}
+
+// SetStyle sets the inline style property of the js object with the value
+func SetStyle(o *js.Object, name string, value string) {
+	o.Get("style").Call("setProperty", name, value)
+}
+
+// RemoveStyle removes the inline style property of the js object
+func RemoveStyle(o *js.Object, name string) {
+	o.Get("style").Call("removeProperty", name)
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNoSnapshot is returned when jumping to a snapshot outside the history.
+var ErrNoSnapshot = errors.New("store: no snapshot at index")
+
+// Snapshot provides a state of a store recorded by a History.
+type Snapshot[S any] struct {
+	Action string    `json:"action"`
+	State  S         `json:"state"`
+	Time   time.Time `json:"time"`
+}
+
+// History records every state of a store produced by a dispatch, allowing the
+// store to be moved back and forth through them. Moving through the history
+// replaces the store's state, so views bound to the store render the restored
+// state. The History is attached to a store by its Middleware.
+type History[S any] struct {
+	ro        sync.RWMutex
+	moves     sync.Mutex
+	store     *Store[S]
+	depth     int
+	snapshots []Snapshot[S]
+	cursor    int
+}
+
+// NewHistory returns a new History keeping up to depth snapshots, a depth of
+// zero keeps all snapshots.
+func NewHistory[S any](depth int) *History[S] {
+	return &History[S]{depth: depth}
+}
+
+// Middleware returns the Middleware recording the store's states, the store's
+// current state is recorded as the first snapshot.
+func (h *History[S]) Middleware() Middleware[S] {
+	return func(s *Store[S], next Dispatch) Dispatch {
+		h.ro.Lock()
+		h.store = s
+		h.snapshots = []Snapshot[S]{{Action: "init", State: s.State(), Time: time.Now()}}
+		h.cursor = 0
+		h.ro.Unlock()
+
+		return func(action Action) {
+			next(action)
+			h.record(fmt.Sprintf("%T", action), s.State())
+		}
+	}
+}
+
+// record adds the state as the latest snapshot, dropping any snapshots which
+// were undone and the oldest snapshots beyond the depth.
+func (h *History[S]) record(action string, state S) {
+	h.ro.Lock()
+	defer h.ro.Unlock()
+
+	h.snapshots = append(h.snapshots[:h.cursor+1], Snapshot[S]{
+		Action: action,
+		State:  state,
+		Time:   time.Now(),
+	})
+
+	if h.depth > 0 && len(h.snapshots) > h.depth {
+		h.snapshots = h.snapshots[len(h.snapshots)-h.depth:]
+	}
+
+	h.cursor = len(h.snapshots) - 1
+}
+
+// Snapshots returns the recorded snapshots, oldest first.
+func (h *History[S]) Snapshots() []Snapshot[S] {
+	h.ro.RLock()
+	defer h.ro.RUnlock()
+	return append([]Snapshot[S](nil), h.snapshots...)
+}
+
+// Cursor returns the index of the snapshot the store is at.
+func (h *History[S]) Cursor() int {
+	h.ro.RLock()
+	defer h.ro.RUnlock()
+	return h.cursor
+}
+
+// Undo moves the store to the previous snapshot, returning false if there is
+// none.
+func (h *History[S]) Undo() bool {
+	h.moves.Lock()
+	defer h.moves.Unlock()
+
+	return h.jump(func(cursor int) int { return cursor - 1 }) == nil
+}
+
+// Redo moves the store to the snapshot which was undone, returning false if
+// there is none.
+func (h *History[S]) Redo() bool {
+	h.moves.Lock()
+	defer h.moves.Unlock()
+
+	return h.jump(func(cursor int) int { return cursor + 1 }) == nil
+}
+
+// Jump moves the store to the snapshot at the index.
+func (h *History[S]) Jump(index int) error {
+	h.moves.Lock()
+	defer h.moves.Unlock()
+
+	return h.jump(func(int) int { return index })
+}
+
+// jump moves the cursor to the index returned for the current cursor and
+// replaces the store's state with its snapshot. Callers hold the moves lock,
+// so concurrent moves restore the store's state in the order they moved the
+// cursor.
+func (h *History[S]) jump(to func(cursor int) int) error {
+	h.ro.Lock()
+	index := to(h.cursor)
+	if h.store == nil || index < 0 || index >= len(h.snapshots) {
+		h.ro.Unlock()
+		return ErrNoSnapshot
+	}
+
+	h.cursor = index
+	store, state := h.store, h.snapshots[index].State
+	h.ro.Unlock()
+
+	store.Replace(state)
+	return nil
+}
+
+// historyJSON provides the json form of a History.
+type historyJSON[S any] struct {
+	Cursor    int           `json:"cursor"`
+	Snapshots []Snapshot[S] `json:"snapshots"`
+}
+
+// Export returns the snapshots and cursor of the history as json.
+func (h *History[S]) Export() ([]byte, error) {
+	h.ro.RLock()
+	defer h.ro.RUnlock()
+
+	return json.Marshal(historyJSON[S]{
+		Cursor:    h.cursor,
+		Snapshots: h.snapshots,
+	})
+}
+
+// Import replaces the history with the exported json, moving the store to the
+// snapshot at its cursor.
+func (h *History[S]) Import(data []byte) error {
+	var hj historyJSON[S]
+	if err := json.Unmarshal(data, &hj); err != nil {
+		return err
+	}
+
+	if hj.Cursor < 0 || hj.Cursor >= len(hj.Snapshots) {
+		return ErrNoSnapshot
+	}
+
+	h.moves.Lock()
+	defer h.moves.Unlock()
+
+	h.ro.Lock()
+	h.snapshots = hj.Snapshots
+	h.ro.Unlock()
+
+	return h.jump(func(int) int { return hj.Cursor })
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/tests"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewHistory[cart](3)
+	st := New(cart{}, reduceCart, history.Middleware())
+
+	items := Select(st, func(c cart) int { return len(c.Items) })
+
+	st.Dispatch(addItem{"Book"})
+	st.Dispatch(addItem{"Funch"})
+	st.Dispatch(addItem{"Fudder"})
+
+	if len(history.Snapshots()) != 3 {
+		tests.FatalFailed(t, "Expected history to keep %d snapshots but got %d", 3, len(history.Snapshots()))
+	}
+
+	if !history.Undo() || !history.Undo() || history.Undo() {
+		tests.FatalFailed(t, "Expected to undo only back to the oldest kept snapshot")
+	}
+
+	if items.Value() != 1 {
+		tests.FatalFailed(t, "Expected undone state to reach selectors but got %d items", items.Value())
+	}
+
+	tests.LogPassed(t, "Successfully undid state changes within the history depth")
+
+	if !history.Redo() || len(st.State().Items) != 2 {
+		tests.FatalFailed(t, "Expected redo to restore the undone state but got %+v", st.State())
+	}
+
+	data, err := history.Export()
+	if err != nil {
+		tests.FatalFailed(t, "Expected history to be exported: %s", err)
+	}
+
+	st.Dispatch(login{"alex"})
+
+	if history.Redo() {
+		tests.FatalFailed(t, "Expected a new dispatch to drop undone snapshots")
+	}
+
+	replay := NewHistory[cart](0)
+	other := New(cart{}, reduceCart, replay.Middleware())
+
+	if err := replay.Import(data); err != nil {
+		tests.FatalFailed(t, "Expected history to be imported: %s", err)
+	}
+
+	if len(other.State().Items) != 2 || replay.Cursor() != 1 {
+		tests.FatalFailed(t, "Expected imported history to restore its cursor state but got %+v", other.State())
+	}
+
+	if err := replay.Jump(0); err != nil || len(other.State().Items) != 1 {
+		tests.FatalFailed(t, "Expected to jump to the first snapshot but got %+v", other.State())
+	}
+
+	tests.LogPassed(t, "Successfully exported and replayed the history")
+}
+
+func TestHistoryConcurrentUndo(t *testing.T) {
+	history := NewHistory[cart](0)
+	st := New(cart{}, reduceCart, history.Middleware())
+
+	for i := 0; i < 20; i++ {
+		st.Dispatch(addItem{"Book"})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			history.Undo()
+		}()
+	}
+	wg.Wait()
+
+	if history.Cursor() != 0 || len(st.State().Items) != 0 {
+		tests.FatalFailed(t, "Expected concurrent undos to reach the first snapshot but at %d with %d items", history.Cursor(), len(st.State().Items))
+	}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			history.Redo()
+		}()
+	}
+	wg.Wait()
+
+	if history.Cursor() != 10 || len(st.State().Items) != 10 {
+		tests.FatalFailed(t, "Expected store to hold the snapshot at the cursor but at %d with %d items", history.Cursor(), len(st.State().Items))
+	}
+
+	tests.LogPassed(t, "Successfully moved through the history concurrently")
+
+	replacing, release := make(chan bool), make(chan bool)
+
+	var once sync.Once
+	st.React(func(r pub.Publisher, err error, data interface{}) {
+		once.Do(func() {
+			replacing <- true
+			<-release
+		})
+	}, true)
+
+	go history.Undo()
+	<-replacing
+
+	undone := make(chan bool)
+	go func() {
+		history.Undo()
+		undone <- true
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+
+	if history.Cursor() != 9 {
+		tests.FatalFailed(t, "Expected undo to wait on the state being replaced but cursor at %d", history.Cursor())
+	}
+
+	release <- true
+	<-undone
+
+	if history.Cursor() != 8 || len(st.State().Items) != 8 {
+		tests.FatalFailed(t, "Expected store to hold the snapshot at the cursor but at %d with %d items", history.Cursor(), len(st.State().Items))
+	}
+
+	tests.LogPassed(t, "Successfully held the history while its state was replaced")
+}
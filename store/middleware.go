@@ -0,0 +1,30 @@
+package store
+
+// Logger returns a Middleware logging every action with the state it resulted
+// in, using a printf style function e.g `log.Printf`.
+func Logger[S any](logf func(format string, v ...interface{})) Middleware[S] {
+	return func(s *Store[S], next Dispatch) Dispatch {
+		return func(action Action) {
+			next(action)
+			logf("store: action %T %+v -> state %+v", action, action, s.State())
+		}
+	}
+}
+
+// ThunkAction defines an action which is run instead of being reduced, it can
+// dispatch other actions at any time e.g once an async request is done.
+type ThunkAction[S any] func(dispatch Dispatch, state func() S)
+
+// Thunk returns a Middleware running ThunkActions with the store's dispatch,
+// passing all other actions on.
+func Thunk[S any]() Middleware[S] {
+	return func(s *Store[S], next Dispatch) Dispatch {
+		return func(action Action) {
+			if thunk, ok := action.(ThunkAction[S]); ok {
+				thunk(s.Dispatch, s.State)
+				return
+			}
+			next(action)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/influx6/haiku/pub"
+)
+
+// Selector provides a pub.Publisher sending a slice of a store's state, only
+// when the slice changed.
+type Selector[S any, T any] struct {
+	pub.Publisher
+	ro     sync.RWMutex
+	value  T
+	equal  func(a, b T) bool
+	choose func(S) T
+}
+
+// Select returns a Selector of the slice of the store's state chosen by the
+// function, slices are compared using reflect.DeepEqual.
+func Select[S any, T any](s *Store[S], choose func(S) T) *Selector[S, T] {
+	return SelectEqual(s, choose, func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// SelectEqual returns a Selector of the slice of the store's state chosen by
+// the function, slices are compared using the equal function.
+func SelectEqual[S any, T any](s *Store[S], choose func(S) T, equal func(a, b T) bool) *Selector[S, T] {
+	sl := &Selector[S, T]{
+		value:  choose(s.State()),
+		equal:  equal,
+		choose: choose,
+	}
+
+	sl.Publisher = s.React(func(r pub.Publisher, err error, data interface{}) {
+		if err != nil {
+			r.ReplyError(err)
+			return
+		}
+
+		state, ok := data.(S)
+		if !ok {
+			return
+		}
+
+		next := sl.choose(state)
+
+		sl.ro.Lock()
+		if sl.equal(sl.value, next) {
+			sl.ro.Unlock()
+			return
+		}
+		sl.value = next
+		sl.ro.Unlock()
+
+		r.Reply(next)
+	}, true)
+
+	return sl
+}
+
+// Value returns the current slice of the state.
+func (sl *Selector[S, T]) Value() T {
+	sl.ro.RLock()
+	defer sl.ro.RUnlock()
+	return sl.value
+}
@@ -0,0 +1,91 @@
+// Package store provides a flux style store holding a single state tree which
+// changes only through actions applied by a reducer, built on pub.Publisher so
+// it composes with the other publishers e.g `pub.Lift(false, actions, store)`.
+package store
+
+import (
+	"sync"
+
+	"github.com/influx6/haiku/pub"
+)
+
+// Action defines an action dispatched to a store, reducers match actions by
+// their Go type.
+type Action interface{}
+
+// Reducer defines a function returning the next state for the action.
+type Reducer[S any] func(state S, action Action) S
+
+// Dispatch defines a function dispatching an action.
+type Dispatch func(Action)
+
+// Middleware defines a function which wraps the dispatch of a store, calling
+// next to pass the action on.
+type Middleware[S any] func(s *Store[S], next Dispatch) Dispatch
+
+// Store holds the state and applies every dispatched action to it using its
+// reducer, sending the new state to its subscribers. Store embeds
+// pub.Publisher where any data sent to it is dispatched as an action.
+type Store[S any] struct {
+	pub.Publisher
+	ro       sync.RWMutex
+	state    S
+	reducer  Reducer[S]
+	dispatch Dispatch
+}
+
+// New returns a new Store with the initial state and reducer, its actions
+// pass through the middleware in the order given before reaching the reducer.
+func New[S any](initial S, reducer Reducer[S], middleware ...Middleware[S]) *Store[S] {
+	s := &Store[S]{
+		state:   initial,
+		reducer: reducer,
+	}
+
+	s.Publisher = pub.Pubb(func(r pub.Publisher, err error, data interface{}) {
+		if err != nil {
+			r.ReplyError(err)
+			return
+		}
+		s.Dispatch(data)
+	})
+
+	s.dispatch = s.reduce
+	for i := len(middleware) - 1; i >= 0; i-- {
+		s.dispatch = middleware[i](s, s.dispatch)
+	}
+
+	return s
+}
+
+// State returns the current state.
+func (s *Store[S]) State() S {
+	s.ro.RLock()
+	defer s.ro.RUnlock()
+	return s.state
+}
+
+// Dispatch passes the action through the store's middleware to its reducer.
+func (s *Store[S]) Dispatch(action Action) {
+	s.dispatch(action)
+}
+
+// Replace sets the state without a dispatch, sending it to the subscribers.
+// It's meant for restoring state e.g from a history.
+func (s *Store[S]) Replace(state S) {
+	s.ro.Lock()
+	s.state = state
+	s.ro.Unlock()
+
+	s.Reply(state)
+}
+
+// reduce applies the action to the state using the reducer.
+func (s *Store[S]) reduce(action Action) {
+	s.ro.Lock()
+	s.state = s.reducer(s.state, action)
+	state := s.state
+	s.ro.Unlock()
+
+	s.Reply(state)
+}
@@ -0,0 +1,98 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type cart struct {
+	Items []string
+	User  string
+}
+
+type addItem struct {
+	Name string
+}
+
+type login struct {
+	User string
+}
+
+func reduceCart(state cart, action Action) cart {
+	switch act := action.(type) {
+	case addItem:
+		state.Items = append(append([]string(nil), state.Items...), act.Name)
+	case login:
+		state.User = act.User
+	}
+	return state
+}
+
+func TestStoreDispatch(t *testing.T) {
+	var logged []string
+
+	st := New(cart{}, reduceCart, Thunk[cart](), Logger[cart](func(format string, v ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, v...))
+	}))
+
+	var states int
+	st.React(func(r pub.Publisher, err error, data interface{}) {
+		states++
+	}, true)
+
+	st.Dispatch(addItem{"Book"})
+	st.Send(login{"alex"})
+
+	st.Dispatch(ThunkAction[cart](func(dispatch Dispatch, state func() cart) {
+		if state().User == "alex" {
+			dispatch(addItem{"Funch"})
+		}
+	}))
+
+	if got := st.State(); len(got.Items) != 2 || got.User != "alex" {
+		tests.FatalFailed(t, "Expected reduced state but got %+v", got)
+	}
+
+	if states != 3 {
+		tests.FatalFailed(t, "Expected %d state changes sent but got %d", 3, states)
+	}
+
+	if len(logged) != 3 || !strings.Contains(logged[0], "addItem") {
+		tests.FatalFailed(t, "Expected every reduced action to be logged but got %q", logged)
+	}
+
+	tests.LogPassed(t, "Successfully dispatched actions through middleware")
+}
+
+func TestSelector(t *testing.T) {
+	st := New(cart{}, reduceCart)
+	items := Select(st, func(c cart) int { return len(c.Items) })
+
+	view := Connect(items, func(count int, m ...string) trees.Markup {
+		return elems.Span(elems.Text(fmt.Sprintf("%d items", count)))
+	})
+
+	var sent int
+	items.React(func(r pub.Publisher, err error, data interface{}) {
+		sent++
+	}, true)
+
+	st.Dispatch(login{"alex"})
+	st.Dispatch(addItem{"Book"})
+
+	if sent != 1 {
+		tests.FatalFailed(t, "Expected selector to send only when its slice changed but sent %d times", sent)
+	}
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "1 items") {
+		tests.FatalFailed(t, "Expected connected view to render the selected slice but got %q", out)
+	}
+
+	tests.LogPassed(t, "Successfully rendered a view connected to a selector")
+}
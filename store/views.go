@@ -0,0 +1,30 @@
+package store
+
+import (
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/views"
+)
+
+// Connected provides a ReactiveRenderable rendering the slice of state of a
+// Selector, so its view renders again only when that slice changes.
+type Connected[S any, T any] struct {
+	pub.Publisher
+	sel    *Selector[S, T]
+	render func(value T, m ...string) trees.Markup
+}
+
+// Connect returns a view rendering the Selector's slice of state using the
+// render function, subscribed to the Selector rather than the whole store.
+func Connect[S any, T any](sel *Selector[S, T], render func(value T, m ...string) trees.Markup) *views.View {
+	return views.NewView(&Connected[S, T]{
+		Publisher: sel.React(pub.IdentityMuxer(), true),
+		sel:       sel,
+		render:    render,
+	})
+}
+
+// Render renders the Selector's current slice of state.
+func (c *Connected[S, T]) Render(m ...string) trees.Markup {
+	return c.render(c.sel.Value(), m...)
+}
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/attrs"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func hasPatch(patches []trees.Patch, op trees.PatchOp, name, value string) bool {
+	for _, p := range patches {
+		if p.Op == op && p.Name == name && p.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDiffAttributesAndText(t *testing.T) {
+	prev := elems.Div(attrs.Class("old"), attrs.ID("box"), elems.Span(elems.Text("20")))
+	next := elems.Div(attrs.Class("new"), elems.Span(elems.Text("30")))
+
+	next.Reconcile(prev)
+	patches := trees.Diff(prev, next)
+
+	tests.Truthy(t, "class attribute set", hasPatch(patches, trees.PatchSetAttr, "class", "new"))
+	tests.Truthy(t, "id attribute removed", hasPatch(patches, trees.PatchRemoveAttr, "id", ""))
+	tests.Truthy(t, "span text replaced", hasPatch(patches, trees.PatchText, "", "30"))
+
+	for _, p := range patches {
+		if p.Op == trees.PatchInsert || p.Op == trees.PatchRemove || p.Op == trees.PatchReplace {
+			tests.FatalFailed(t, "Expected no structural patches but got %+v", p)
+		}
+	}
+}
+
+func TestDiffChildren(t *testing.T) {
+	prev := elems.Div(elems.Span(elems.Text("a")), elems.Anchor(elems.Text("b")))
+	next := elems.Div(elems.Span(elems.Text("a")), elems.Label(elems.Text("c")), elems.Paragraph())
+
+	next.Reconcile(prev)
+	patches := trees.Diff(prev, next)
+
+	var inserts, removes int
+	for _, p := range patches {
+		switch p.Op {
+		case trees.PatchInsert:
+			inserts++
+		case trees.PatchRemove:
+			removes++
+			if p.Index != 1 {
+				tests.FatalFailed(t, "Expected removal of child at %d but got %d", 1, p.Index)
+			}
+		}
+	}
+
+	if inserts != 2 || removes != 1 {
+		tests.FatalFailed(t, "Expected %d inserts and %d removals but got %d and %d", 2, 1, inserts, removes)
+	}
+
+	tests.LogPassed(t, "Successfully diffed children of reconciled markup")
+}
+
+func TestDiffReplace(t *testing.T) {
+	prev := elems.Div()
+	next := elems.Section()
+
+	patches := trees.Diff(prev, next)
+
+	if len(patches) != 1 || patches[0].Op != trees.PatchReplace || patches[0].UID != prev.UID() {
+		tests.FatalFailed(t, "Expected a single replace patch but got %+v", patches)
+	}
+
+	tests.LogPassed(t, "Successfully replaced markup with a different tag")
+}
+
+func TestDiffKeyedChildren(t *testing.T) {
+	row := func(key string) *trees.Element {
+		return elems.Div(attrs.Key(key), elems.Text("row "+key))
+	}
+
+	prev := elems.Div(row("1"), row("2"), row("3"))
+	next := elems.Div(row("2"), row("3"), row("4"))
+
+	uid := prev.Children()[1].UID()
+
+	next.Reconcile(prev)
+
+	if next.Children()[0].UID() != uid {
+		tests.FatalFailed(t, "Expected keyed row to keep its uid when moved")
+	}
+
+	var inserts, removes, texts int
+	for _, p := range trees.Diff(prev, next) {
+		switch p.Op {
+		case trees.PatchInsert:
+			inserts++
+		case trees.PatchRemove:
+			removes++
+		case trees.PatchText:
+			texts++
+		}
+	}
+
+	if inserts != 1 || removes != 1 || texts != 0 {
+		tests.FatalFailed(t, "Expected a single insert and removal but got %d inserts, %d removals and %d text patches", inserts, removes, texts)
+	}
+
+	tests.LogPassed(t, "Successfully reconciled keyed children by their key")
+}
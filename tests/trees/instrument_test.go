@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type printRecord struct {
+	bytes int
+}
+
+func (p *printRecord) Printed(stat trees.PrintStat) {
+	p.bytes += stat.Bytes
+}
+
+func TestReconcileWithStat(t *testing.T) {
+	prev := elems.Div(elems.Span(elems.Text("a")), elems.Anchor(elems.Text("b")))
+	next := elems.Div(elems.Span(elems.Text("a")), elems.Label(elems.Text("c")))
+
+	_, first := trees.ReconcileWithStat(prev, nil)
+	if first.Created != 5 {
+		tests.FatalFailed(t, "Expected %d created nodes on first render but got %d", 5, first.Created)
+	}
+
+	_, stat := trees.ReconcileWithStat(next, prev)
+
+	if stat.Reused != 3 || stat.Created != 2 || stat.Removed != 2 {
+		tests.FatalFailed(t, "Expected 3 reused, 2 created and 2 removed nodes but got %+v", stat)
+	}
+
+	tests.LogPassed(t, "Successfully tallied reconciled nodes")
+}
+
+func TestInstrumentWriter(t *testing.T) {
+	var record printRecord
+
+	writer := trees.InstrumentWriter(trees.SimpleMarkupWriter, &record)
+	out, err := writer.Write(elems.Div(elems.Text("hello")))
+	if err != nil {
+		tests.FatalFailed(t, "Expected markup to be written: %s", err)
+	}
+
+	tests.Truthy(t, "printed bytes reported", record.bytes == len(out))
+}
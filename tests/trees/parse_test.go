@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func TestParseHTML(t *testing.T) {
+	markups, err := trees.ParseHTML(`
+		<div class="item" style="color: red; width:20px">
+			<span>Joyride &amp; Lewis!</span>
+			<input type="text">
+		</div>
+	`)
+
+	if err != nil {
+		tests.FatalFailed(t, "Expected html to be parsed: %s", err)
+	}
+
+	if len(markups) != 1 {
+		tests.FatalFailed(t, "Expected %d top level markup but got %d", 1, len(markups))
+	}
+
+	div := markups[0]
+
+	tests.Truthy(t, "class attribute parsed", trees.AttrContains(div, "class", "item"))
+	tests.Truthy(t, "styles parsed", trees.StyleContains(div, "width", "20px"))
+
+	children := div.Children()
+	if len(children) != 2 {
+		tests.FatalFailed(t, "Expected whitespace texts to be skipped but got %d children", len(children))
+	}
+
+	tests.Truthy(t, "input is auto closed", children[1].AutoClosed())
+	tests.Truthy(t, "text is kept escaped", children[0].Children()[0].TextContent() == "Joyride &amp; Lewis!")
+}
+
+func TestPrintParsedHTML(t *testing.T) {
+	markups, err := trees.ParseHTML(`<p title="&#34;quoted&#34;">&lt;script&gt;</p><script>if (a < b) {}</script>`)
+	if err != nil {
+		tests.FatalFailed(t, "Expected html to be parsed: %s", err)
+	}
+
+	para, _ := trees.SimpleMarkupWriter.Write(markups[0])
+	script, _ := trees.SimpleMarkupWriter.Write(markups[1])
+
+	tests.Truthy(t, "text is escaped", strings.Contains(para, "&lt;script&gt;"))
+	tests.Truthy(t, "attribute is escaped", strings.Contains(para, `title="&#34;quoted&#34;"`))
+	tests.Truthy(t, "script is not escaped", strings.Contains(script, "a < b"))
+}
+
+func TestPrintParsedStyles(t *testing.T) {
+	markups, err := trees.ParseHTML(`<p style='font-family: "Open Sans", serif'>Hi</p>`)
+	if err != nil {
+		tests.FatalFailed(t, "Expected html to be parsed: %s", err)
+	}
+
+	out, _ := trees.SimpleMarkupWriter.Write(markups[0])
+	tests.Truthy(t, "style value is escaped", strings.Contains(out, `font-family:&#34;Open Sans&#34;, serif;`))
+}
+
+func TestPrintMarkupAsIs(t *testing.T) {
+	out, _ := trees.SimpleMarkupWriter.Write(elems.Paragraph(
+		trees.NewAttr("title", "Fish &amp; Chips"),
+		elems.Text("&copy; 2016 <b>bold</b>"),
+	))
+
+	tests.Truthy(t, "entities are kept", strings.Contains(out, "&copy; 2016"))
+	tests.Truthy(t, "text markup is kept", strings.Contains(out, "<b>bold</b>"))
+	tests.Truthy(t, "attribute entities are kept", strings.Contains(out, `title="Fish &amp; Chips"`))
+}
+
+func TestParseLooseHTML(t *testing.T) {
+	markups, err := trees.ParseHTML(`<ul><li>one<li>two</ul><!-- skipped --><p>a &copy; b<br>c<p>d`)
+	if err != nil {
+		tests.FatalFailed(t, "Expected loose html to be parsed: %s", err)
+	}
+
+	if len(markups) != 3 {
+		tests.FatalFailed(t, "Expected %d top level markups but got %d", 3, len(markups))
+	}
+
+	tests.Truthy(t, "list items are siblings", len(markups[0].Children()) == 2)
+	tests.Truthy(t, "paragraph is ended by the next", len(markups[1].Children()) == 3 && markups[2].Name() == "p")
+
+	out, _ := trees.SimpleMarkupWriter.Write(markups[1])
+	tests.Truthy(t, "entity is decoded", strings.Contains(out, "a © b"))
+}
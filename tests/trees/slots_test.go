@@ -0,0 +1,30 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func TestFillSlots(t *testing.T) {
+	header := elems.Header(elems.Text("Books"))
+
+	layout := elems.Div(
+		elems.Section(trees.Slot("header", elems.Text("Untitled"))),
+		trees.Slot("footer", elems.Text("No footer")),
+	)
+
+	trees.FillSlots(layout, map[string][]trees.Markup{
+		"header": {header},
+	})
+
+	slot := layout.Children()[0].Children()[0]
+	if len(slot.Children()) != 1 || slot.Children()[0] != header {
+		tests.FatalFailed(t, "Expected nested slot to hold its fill but got %d children", len(slot.Children()))
+	}
+
+	footer := layout.Children()[1]
+	tests.Truthy(t, "unfilled slot keeps its default", footer.Children()[0].TextContent() == "No footer")
+}
@@ -135,3 +135,9 @@ func Placeholder(val string) *trees.Attribute {
 func Value(val string) *trees.Attribute {
 	return &trees.Attribute{Name: "value", Value: val}
 }
+
+// Key defines the "key" attribute used to reconcile children by identity
+// rather than position.
+func Key(val string) *trees.Attribute {
+	return &trees.Attribute{Name: "key", Value: val}
+}
@@ -0,0 +1,204 @@
+package trees
+
+// PatchOp defines the type of change a Patch applies to a live dom node.
+type PatchOp int
+
+// Operations produced by Diff. Element nodes are addressed by their uid while
+// child nodes (which includes text nodes that carry no uid in the dom) are
+// addressed by their parent's uid and their position within it.
+const (
+	// PatchReplace replaces the node with the uid with the patch markup.
+	PatchReplace PatchOp = iota
+
+	// PatchInsert inserts the patch markup as the child at Index.
+	PatchInsert
+
+	// PatchMove moves the child at From to Index.
+	PatchMove
+
+	// PatchRemove removes the child at Index.
+	PatchRemove
+
+	// PatchText sets the content of the text child at Index.
+	PatchText
+
+	// PatchSetAttr sets the attribute Name to Value.
+	PatchSetAttr
+
+	// PatchRemoveAttr removes the attribute Name.
+	PatchRemoveAttr
+
+	// PatchSetStyle sets the style property Name to Value.
+	PatchSetStyle
+
+	// PatchRemoveStyle removes the style property Name.
+	PatchRemoveStyle
+)
+
+// Patch defines a single node level change to be applied to the live dom.
+type Patch struct {
+	Op     PatchOp
+	UID    string
+	Index  int
+	From   int
+	Name   string
+	Value  string
+	Markup Markup
+}
+
+// Diff walks a markup reconciled against its previous render and returns the
+// minimal set of patches needed to turn the dom of the previous render into
+// the dom of the new one. The previous markup is expected to mirror the live
+// dom, which is the case when its patches were applied in the prior render.
+func Diff(prev, next Markup) []Patch {
+	var patches []Patch
+
+	if prev.Name() != next.Name() || prev.UID() != next.UID() {
+		return append(patches, Patch{Op: PatchReplace, UID: prev.UID(), Markup: next})
+	}
+
+	diffElement(prev, next, &patches)
+	return patches
+}
+
+// diffElement adds the patches for the attributes, styles and children of two
+// element markups sharing the same uid.
+func diffElement(prev, next Markup, patches *[]Patch) {
+	uid := next.UID()
+
+	if prev.Hash() != next.Hash() {
+		*patches = append(*patches, Patch{Op: PatchSetAttr, UID: uid, Name: "hash", Value: next.Hash()})
+	}
+
+	oldAttrs := make(map[string]string)
+	for _, attr := range prev.Attributes() {
+		oldAttrs[attr.Name] = attr.Value
+	}
+
+	newAttrs := make(map[string]string)
+	for _, attr := range next.Attributes() {
+		newAttrs[attr.Name] = attr.Value
+	}
+
+	for _, attr := range next.Attributes() {
+		if val, ok := oldAttrs[attr.Name]; ok && val == newAttrs[attr.Name] {
+			continue
+		}
+
+		oldAttrs[attr.Name] = newAttrs[attr.Name]
+		*patches = append(*patches, Patch{Op: PatchSetAttr, UID: uid, Name: attr.Name, Value: newAttrs[attr.Name]})
+	}
+
+	for _, attr := range prev.Attributes() {
+		if _, ok := newAttrs[attr.Name]; ok {
+			continue
+		}
+
+		newAttrs[attr.Name] = ""
+		*patches = append(*patches, Patch{Op: PatchRemoveAttr, UID: uid, Name: attr.Name})
+	}
+
+	oldStyles := make(map[string]string)
+	for _, style := range prev.Styles() {
+		oldStyles[style.Name] = style.Value
+	}
+
+	newStyles := make(map[string]string)
+	for _, style := range next.Styles() {
+		newStyles[style.Name] = style.Value
+	}
+
+	for _, style := range next.Styles() {
+		if val, ok := oldStyles[style.Name]; ok && val == newStyles[style.Name] {
+			continue
+		}
+
+		oldStyles[style.Name] = newStyles[style.Name]
+		*patches = append(*patches, Patch{Op: PatchSetStyle, UID: uid, Name: style.Name, Value: newStyles[style.Name]})
+	}
+
+	for _, style := range prev.Styles() {
+		if _, ok := newStyles[style.Name]; ok {
+			continue
+		}
+
+		newStyles[style.Name] = ""
+		*patches = append(*patches, Patch{Op: PatchRemoveStyle, UID: uid, Name: style.Name})
+	}
+
+	diffChildren(prev, next, patches)
+}
+
+// diffChildren adds the patches which turn the children of the previous markup
+// into the live children of the new one, recursing into retained children.
+func diffChildren(prev, next Markup, patches *[]Patch) {
+	uid := next.UID()
+
+	var live []Markup
+	liveSet := make(map[string]bool)
+
+	for _, ch := range next.Children() {
+		if ch.Removed() {
+			continue
+		}
+
+		live = append(live, ch)
+		liveSet[ch.UID()] = true
+	}
+
+	// current tracks the uids of the dom children as the patches get applied.
+	var current []string
+	retained := make(map[string]Markup)
+
+	for _, ch := range prev.Children() {
+		current = append(current, ch.UID())
+		if liveSet[ch.UID()] {
+			retained[ch.UID()] = ch
+		}
+	}
+
+	// remove from the back so the indexes of the remaining children hold.
+	for index := len(current) - 1; index >= 0; index-- {
+		if _, ok := retained[current[index]]; ok {
+			continue
+		}
+
+		*patches = append(*patches, Patch{Op: PatchRemove, UID: uid, Index: index})
+		current = append(current[:index], current[index+1:]...)
+	}
+
+	for index, ch := range live {
+		old, ok := retained[ch.UID()]
+		if !ok {
+			*patches = append(*patches, Patch{Op: PatchInsert, UID: uid, Index: index, Markup: ch})
+			current = append(current[:index], append([]string{ch.UID()}, current[index:]...)...)
+			continue
+		}
+
+		if current[index] != ch.UID() {
+			from := index
+			for current[from] != ch.UID() {
+				from++
+			}
+
+			*patches = append(*patches, Patch{Op: PatchMove, UID: uid, Index: index, From: from})
+			current = append(current[:from], current[from+1:]...)
+			current = append(current[:index], append([]string{ch.UID()}, current[index:]...)...)
+		}
+
+		if old.Name() != ch.Name() {
+			*patches = append(*patches, Patch{Op: PatchRemove, UID: uid, Index: index})
+			*patches = append(*patches, Patch{Op: PatchInsert, UID: uid, Index: index, Markup: ch})
+			continue
+		}
+
+		if ch.Name() == "text" {
+			if old.TextContent() != ch.TextContent() {
+				*patches = append(*patches, Patch{Op: PatchText, UID: uid, Index: index, Value: ch.TextContent()})
+			}
+			continue
+		}
+
+		diffElement(old, ch, patches)
+	}
+}
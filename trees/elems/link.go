@@ -0,0 +1,21 @@
+package elems
+
+import "github.com/influx6/haiku/trees"
+
+// Router defines a type building the urls of named routes e.g views.Pages.
+type Router interface {
+	URL(name string, params map[string]string) (string, error)
+}
+
+// RouteLink provides an Anchor to the url of the named route, built by the Router
+// from the params. The anchor is left without a href if the route can't be
+// built, e.g as its name is unknown or a parameter is missing.
+func RouteLink(r Router, name string, params map[string]string, markup ...trees.Appliable) *trees.Element {
+	e := Anchor(markup...)
+
+	if url, err := r.URL(name, params); err == nil {
+		trees.NewAttr("href", url).Apply(e)
+	}
+
+	return e
+}
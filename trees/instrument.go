@@ -0,0 +1,93 @@
+package trees
+
+import "time"
+
+// ReconcileStat provides the tally of the nodes of a markup after it was
+// reconciled against its previous render.
+type ReconcileStat struct {
+	Created  int // nodes not found in the previous render.
+	Reused   int // nodes which took up the uid of a node in the previous render.
+	Removed  int // nodes of the previous render no longer rendered.
+	Duration time.Duration
+}
+
+// PrintStat provides the size and time taken to print a markup.
+type PrintStat struct {
+	Bytes    int
+	Duration time.Duration
+}
+
+// Instrument defines a type which receives the measurements of printing
+// markups through InstrumentWriter.
+type Instrument interface {
+	Printed(PrintStat)
+}
+
+// ReconcileWithStat reconciles the markup against its previous render,
+// returning if it changed and the tally of its nodes. If prev is nil all nodes
+// of the markup are counted as created.
+func ReconcileWithStat(next, prev Markup) (bool, ReconcileStat) {
+	var stat ReconcileStat
+
+	if prev == nil {
+		walkLive(next, func(Markup) { stat.Created++ })
+		return true, stat
+	}
+
+	old := make(map[string]bool)
+	walkLive(prev, func(m Markup) { old[m.UID()] = true })
+
+	start := time.Now()
+	changed := next.Reconcile(prev)
+	stat.Duration = time.Since(start)
+
+	walkLive(next, func(m Markup) {
+		if old[m.UID()] {
+			stat.Reused++
+			return
+		}
+		stat.Created++
+	})
+
+	stat.Removed = len(old) - stat.Reused
+	return changed, stat
+}
+
+// walkLive calls the function with the markup and all its children which have
+// not been removed.
+func walkLive(m Markup, fx func(Markup)) {
+	if m.Removed() {
+		return
+	}
+
+	fx(m)
+
+	for _, ch := range m.Children() {
+		walkLive(ch, fx)
+	}
+}
+
+// instrumentedWriter provides a MarkupWriter which reports the size and time
+// of every write to an Instrument.
+type instrumentedWriter struct {
+	MarkupWriter
+	in Instrument
+}
+
+// InstrumentWriter returns a MarkupWriter which writes through the given
+// writer, reporting every printed markup to the Instrument.
+func InstrumentWriter(w MarkupWriter, in Instrument) MarkupWriter {
+	return &instrumentedWriter{MarkupWriter: w, in: in}
+}
+
+// Write writes the markup, reporting its printed size to the Instrument.
+func (i *instrumentedWriter) Write(m Markup) (string, error) {
+	start := time.Now()
+	out, err := i.MarkupWriter.Write(m)
+	if err != nil {
+		return out, err
+	}
+
+	i.in.Printed(PrintStat{Bytes: len(out), Duration: time.Since(start)})
+	return out, nil
+}
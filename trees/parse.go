@@ -0,0 +1,220 @@
+package trees
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// voidElements provides the elements which have no ending tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// rawTextElements provides the elements whose text content is kept as is,
+// rather than as markup.
+var rawTextElements = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// impliedEnds provides the elements whose start tag ends the listed elements
+// when they're the innermost open ones, as html allows their end tags to be
+// left out e.g `<li>one<li>two`.
+var impliedEnds = map[string][]string{
+	"li":       {"li"},
+	"dt":       {"dt", "dd"},
+	"dd":       {"dt", "dd"},
+	"option":   {"option"},
+	"optgroup": {"option", "optgroup"},
+	"tr":       {"td", "th", "tr"},
+	"td":       {"td", "th"},
+	"th":       {"td", "th"},
+	"p":        {"p"},
+	"div":      {"p"},
+	"ul":       {"p"},
+	"ol":       {"p"},
+	"dl":       {"p"},
+	"pre":      {"p"},
+	"table":    {"p"},
+	"form":     {"p"},
+	"section":  {"p"},
+	"article":  {"p"},
+	"header":   {"p"},
+	"footer":   {"p"},
+	"h1":       {"p"},
+	"h2":       {"p"},
+	"h3":       {"p"},
+	"h4":       {"p"},
+	"h5":       {"p"},
+	"h6":       {"p"},
+	"hr":       {"p"},
+}
+
+// rawTextSections provides the patterns of the elements whose content isn't
+// parsed as markup.
+var rawTextSections = []*regexp.Regexp{
+	regexp.MustCompile(`(?is)(<script\b[^>]*>)(.*?)(</script\s*>)`),
+	regexp.MustCompile(`(?is)(<style\b[^>]*>)(.*?)(</style\s*>)`),
+}
+
+// fragmentTag names the element the parsed fragment is wrapped within.
+const fragmentTag = "haiku-fragment"
+
+// ParseHTML parses the html fragment into markup, returning the elements and
+// texts at the top of the fragment. Comments are skipped, as are texts of only
+// whitespace outside of pre and textarea elements. The style attribute is
+// parsed into the element's styles. As markup built by hand, texts and
+// attribute values are kept escaped so they print back out as parsed.
+func ParseHTML(content string) ([]Markup, error) {
+	for _, rx := range rawTextSections {
+		content = rx.ReplaceAllStringFunc(content, func(section string) string {
+			parts := rx.FindStringSubmatch(section)
+			body := strings.Replace(parts[2], "]]>", "]]]]><![CDATA[>", -1)
+			return parts[1] + "<![CDATA[" + body + "]]>" + parts[3]
+		})
+	}
+
+	dec := xml.NewDecoder(strings.NewReader("<" + fragmentTag + ">" + content + "</" + fragmentTag + ">"))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	root := NewElement(fragmentTag, false)
+	open := []*Element{root}
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch tk := token.(type) {
+		case xml.StartElement:
+			name := tagName(tk.Name)
+			if name == fragmentTag {
+				continue
+			}
+
+			for len(open) > 1 && impliesEnd(name, open[len(open)-1].Name()) {
+				open = open[:len(open)-1]
+			}
+
+			e := NewElement(name, voidElements[name])
+
+			for _, attr := range tk.Attr {
+				key := tagName(attr.Name)
+
+				if key == "style" {
+					for _, st := range parseStyles(attr.Value) {
+						st.Apply(e)
+					}
+					continue
+				}
+
+				NewAttr(key, html.EscapeString(attr.Value)).Apply(e)
+			}
+
+			open[len(open)-1].AddChild(e)
+			open = append(open, e)
+
+		case xml.EndElement:
+			name := tagName(tk.Name)
+
+			// end tags of elements already ended are skipped.
+			for i := len(open) - 1; i > 0; i-- {
+				if open[i].Name() == name {
+					open = open[:i]
+					break
+				}
+			}
+
+		case xml.CharData:
+			text := string(tk)
+			top := open[len(open)-1]
+
+			if !preserved(open) && strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			if rawTextElements[top.Name()] {
+				top.AddChild(NewText(text))
+				continue
+			}
+
+			top.AddChild(NewText(html.EscapeString(text)))
+		}
+	}
+
+	return root.Children(), nil
+}
+
+// tagName returns the lowercased name of an element or attribute.
+func tagName(name xml.Name) string {
+	if name.Space != "" {
+		return strings.ToLower(name.Space + ":" + name.Local)
+	}
+	return strings.ToLower(name.Local)
+}
+
+// impliesEnd returns true if the start of the named element ends the open one.
+func impliesEnd(name, open string) bool {
+	for _, ends := range impliedEnds[name] {
+		if ends == open {
+			return true
+		}
+	}
+	return false
+}
+
+// preserved returns true if whitespace is kept within the innermost of the
+// open elements.
+func preserved(open []*Element) bool {
+	for _, e := range open {
+		if e.Name() == "pre" || e.Name() == "textarea" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStyles returns the styles within the value of a style attribute, their
+// values are escaped as they're printed within the attribute.
+func parseStyles(val string) []*Style {
+	var styles []*Style
+
+	for _, decl := range strings.Split(val, ";") {
+		parts := strings.SplitN(decl, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			continue
+		}
+
+		styles = append(styles, NewStyle(name, html.EscapeString(strings.TrimSpace(parts[1]))))
+	}
+
+	return styles
+}
@@ -0,0 +1,43 @@
+package trees
+
+// Slot returns a slot element marking where the content supplied for the
+// named slot is projected, the defaults are rendered if none is supplied.
+func Slot(name string, defaults ...Markup) *Element {
+	e := NewElement("slot", false)
+	NewAttr("name", name).Apply(e)
+
+	for _, m := range defaults {
+		e.AddChild(m)
+	}
+
+	return e
+}
+
+// FillSlots replaces the children of the named slots within the markup with
+// their fills, slots without a fill keep their default children.
+func FillSlots(root Markup, fills map[string][]Markup) {
+	if len(fills) == 0 {
+		return
+	}
+
+	el, ok := root.(*Element)
+	if !ok {
+		return
+	}
+
+	if el.Name() == "slot" {
+		if name, err := GetAttr(el, "name"); err == nil {
+			if fill, ok := fills[name.Value]; ok {
+				el.Empty()
+				for _, m := range fill {
+					el.AddChild(m)
+				}
+				return
+			}
+		}
+	}
+
+	for _, ch := range el.children {
+		FillSlots(ch, fills)
+	}
+}
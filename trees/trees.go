@@ -220,6 +220,11 @@ func (e *Element) Empty() {
 	e.children = e.children[:0]
 }
 
+// EmptyEvents resets the elements events list as 0 length
+func (e *Element) EmptyEvents() {
+	e.events = e.events[:0]
+}
+
 // Name returns the tag name of the element
 func (e *Element) Name() string {
 	return e.tagname
@@ -232,14 +237,18 @@ func (e *Element) TextContent() string {
 
 // CleanRemoved removes all the chilren marked as removed
 func (e *Element) CleanRemoved() {
-	for n, em := range e.children {
+	children := e.children[:0]
+
+	for _, em := range e.children {
 		if em.Removed() {
-			copy(e.children[n:], e.children[n+1:])
-			e.children = e.children[:len(e.children)-1]
-		} else {
-			em.CleanRemoved()
+			continue
 		}
+
+		em.CleanRemoved()
+		children = append(children, em)
 	}
+
+	e.children = children
 }
 
 // Augment provides a generic method for markup addition
@@ -361,26 +370,30 @@ func (e *Element) Reconcile(em Markup) bool {
 
 	var childChanged bool
 
-	for n, och := range oldChildren {
-		if maxSize > n {
-			nch := newChildren[n]
-
-			// log.Printf("checking old (%s) with new(%s)", och.Name(), nch.Name())
-
-			if nch.Name() == och.Name() {
-				if nch.Reconcile(och) {
-					// log.Printf("old (%s) with new(%s) changed!", och.Name(), nch.Name())
-					childChanged = true
+	if hasKeys(newChildren) {
+		childChanged = e.reconcileKeyed(newChildren, oldChildren)
+	} else {
+		for n, och := range oldChildren {
+			if maxSize > n {
+				nch := newChildren[n]
+
+				// log.Printf("checking old (%s) with new(%s)", och.Name(), nch.Name())
+
+				if nch.Name() == och.Name() {
+					if nch.Reconcile(och) {
+						// log.Printf("old (%s) with new(%s) changed!", och.Name(), nch.Name())
+						childChanged = true
+					}
+				} else {
+					och.Remove()
+					e.AddChild(och)
 				}
-			} else {
-				och.Remove()
-				e.AddChild(och)
+				continue
 			}
-			continue
-		}
 
-		och.Remove()
-		e.AddChild(och)
+			och.Remove()
+			e.AddChild(och)
+		}
 	}
 
 	ReconcileEvents(e, em)
@@ -406,6 +419,71 @@ func (e *Element) Reconcile(em Markup) bool {
 	return true
 }
 
+// reconcileKeyed reconciles the children against the old children sharing
+// their key attribute rather than their position, so keyed children keep their
+// uid when they move. Children whose key is not found are new, while old
+// children whose key is gone are marked removed. It returns true if any child
+// changed, moved, was added or removed.
+func (e *Element) reconcileKeyed(newChildren, oldChildren []Markup) bool {
+	keyed := make(map[string]Markup)
+	for _, och := range oldChildren {
+		if key := keyOf(och); key != "" {
+			keyed[key] = och
+		}
+	}
+
+	var changed bool
+	used := make(map[Markup]bool)
+
+	for n, nch := range newChildren {
+		och, ok := keyed[keyOf(nch)]
+		if !ok || used[och] || och.Name() != nch.Name() {
+			changed = true
+			continue
+		}
+
+		used[och] = true
+
+		if nch.Reconcile(och) {
+			changed = true
+		}
+
+		if n >= len(oldChildren) || oldChildren[n] != och {
+			changed = true
+		}
+	}
+
+	for _, och := range oldChildren {
+		if used[och] {
+			continue
+		}
+
+		changed = true
+		och.Remove()
+		e.AddChild(och)
+	}
+
+	return changed
+}
+
+// hasKeys returns true if any of the children has a key attribute.
+func hasKeys(children []Markup) bool {
+	for _, ch := range children {
+		if keyOf(ch) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// keyOf returns the value of the markup's key attribute, else an empty string.
+func keyOf(m Markup) string {
+	if attr, err := GetAttr(m, "key"); err == nil {
+		return attr.Value
+	}
+	return ""
+}
+
 // MarkupChildren defines the interface of an element that has children
 type MarkupChildren interface {
 	AddChild(Markup)
@@ -701,6 +779,28 @@ func Augment(root Markup, m ...Markup) {
 	}
 }
 
+// Replace swaps the markup within the root's tree with another, returning true
+// if it was found.
+func Replace(root, old, with Markup) bool {
+	el, ok := root.(*Element)
+	if !ok {
+		return false
+	}
+
+	for index, ch := range el.children {
+		if ch == old {
+			el.children[index] = with
+			return true
+		}
+
+		if Replace(ch, old, with) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReconcileEvents checks through two markup events against each other and if it finds any disparity marks
 // event objects as Removed
 func ReconcileEvents(e, em Markup) {
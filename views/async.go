@@ -0,0 +1,156 @@
+package views
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-humble/detect"
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+// AsyncRenderable provides a ReactiveRenderable which renders the data
+// delivered by a publisher, rendering a loading markup until the publisher
+// sends its data or error. It stops listening to the publisher when its view
+// is hidden and listens again once shown.
+type AsyncRenderable struct {
+	pub.Publisher
+	source   pub.Publisher
+	loading  func() trees.Markup
+	ready    func(interface{}) trees.Markup
+	failed   func(error) trees.Markup
+	ro       sync.RWMutex
+	sub      pub.Publisher
+	done     chan struct{}
+	settled  bool
+	data     interface{}
+	err      error
+	deadline time.Duration
+}
+
+// Async returns a new AsyncRenderable listening to the source. The ready
+// markup is rendered with the data sent by the source, and the failed markup
+// with the error it sent.
+func Async(source pub.Publisher, loading func() trees.Markup, ready func(interface{}) trees.Markup, failed func(error) trees.Markup) *AsyncRenderable {
+	a := AsyncRenderable{
+		Publisher: pub.Identity(),
+		source:    source,
+		loading:   loading,
+		ready:     ready,
+		failed:    failed,
+		done:      make(chan struct{}),
+	}
+
+	a.subscribe()
+
+	return &a
+}
+
+// UseDeadline sets how long a render on the server waits for the source before
+// rendering the loading markup. It returns the AsyncRenderable to allow
+// chaining e.g `NewView(Async(...).UseDeadline(time.Second))`.
+func (a *AsyncRenderable) UseDeadline(d time.Duration) *AsyncRenderable {
+	a.ro.Lock()
+	a.deadline = d
+	a.ro.Unlock()
+	return a
+}
+
+// Cancel stops listening to the source, leaving the current markup rendered.
+func (a *AsyncRenderable) Cancel() {
+	a.ro.Lock()
+	sub := a.sub
+	a.sub = nil
+	a.ro.Unlock()
+
+	if sub != nil {
+		a.source.Detach(sub)
+	}
+}
+
+// OnHide cancels listening to the source when the view is hidden.
+func (a *AsyncRenderable) OnHide() {
+	a.Cancel()
+}
+
+// OnShow listens to the source again when the view is shown.
+func (a *AsyncRenderable) OnShow() {
+	a.subscribe()
+}
+
+// subscribe listens to the source if not already listening.
+func (a *AsyncRenderable) subscribe() {
+	a.ro.Lock()
+	defer a.ro.Unlock()
+
+	if a.sub != nil {
+		return
+	}
+
+	a.sub = a.source.React(a.receive, false)
+}
+
+// receive stores the data or error sent by the source and requests a render.
+func (a *AsyncRenderable) receive(r pub.Publisher, err error, data interface{}) {
+	a.ro.Lock()
+
+	// ignore deliveries made to a cancelled listener.
+	if a.sub != r {
+		a.ro.Unlock()
+		return
+	}
+
+	a.data, a.err = data, err
+
+	if !a.settled {
+		a.settled = true
+		close(a.done)
+	}
+
+	a.ro.Unlock()
+
+	a.Send(true)
+}
+
+// Render renders the loading markup until the source sends data or an error.
+// On the server it waits up to its deadline for the source.
+func (a *AsyncRenderable) Render(m ...string) trees.Markup {
+	a.ro.RLock()
+	deadline := a.deadline
+	a.ro.RUnlock()
+
+	if deadline > 0 && !detect.IsBrowser() {
+		select {
+		case <-a.done:
+		case <-time.After(deadline):
+		}
+	}
+
+	a.ro.RLock()
+	settled, data, err := a.settled, a.data, a.err
+	a.ro.RUnlock()
+
+	var dom trees.Markup
+
+	switch {
+	case !settled:
+		if a.loading != nil {
+			dom = a.loading()
+		}
+	case err != nil:
+		if a.failed != nil {
+			dom = a.failed(err)
+		}
+	default:
+		if a.ready != nil {
+			dom = a.ready(data)
+		}
+	}
+
+	if dom == nil {
+		return elems.Div()
+	}
+
+	return dom
+}
@@ -0,0 +1,74 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func asyncItem(source pub.Publisher) *AsyncRenderable {
+	return Async(source, func() trees.Markup {
+		return elems.Span(elems.Text("loading"))
+	}, func(data interface{}) trees.Markup {
+		return elems.Span(elems.Text(fmt.Sprintf("+ %s", data)))
+	}, func(err error) trees.Markup {
+		return elems.Span(elems.Text(err.Error()))
+	})
+}
+
+func TestAsync(t *testing.T) {
+	source := pub.Identity()
+	view := NewView(asyncItem(source))
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "loading") {
+		fatalFailed(t, "Expected loading markup but got %q", out)
+	}
+
+	source.Send("Book")
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "+ Book") {
+		fatalFailed(t, "Expected ready markup but got %q", out)
+	}
+
+	logPassed(t, "Successfully swapped loading markup for ready markup")
+
+	view.Hide()
+	source.Send("Funch")
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "+ Book") {
+		fatalFailed(t, "Expected hidden view to ignore the source but got %q", out)
+	}
+
+	logPassed(t, "Successfully cancelled listening when hidden")
+
+	view.Show()
+	source.SendError(errors.New("no connection"))
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "no connection") {
+		fatalFailed(t, "Expected failed markup but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered failed markup after being shown")
+}
+
+func TestAsyncDeadline(t *testing.T) {
+	source := pub.Identity()
+	view := NewView(asyncItem(source).UseDeadline(time.Second))
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		source.Send("Book")
+	}()
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "+ Book") {
+		fatalFailed(t, "Expected server render to wait for the source but got %q", out)
+	}
+
+	logPassed(t, "Successfully waited for the source within the deadline")
+}
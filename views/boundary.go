@@ -0,0 +1,98 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+// Fallbacker defines a Renderable which provides the markup to be rendered in
+// its place when its render fails.
+type Fallbacker interface {
+	Fallback(err error) trees.Markup
+}
+
+// RenderError is the error reported by a view when its Renderable panics while
+// rendering.
+type RenderError struct {
+	UID    string
+	Reason interface{}
+}
+
+// Error returns the error message of the failed render.
+func (r *RenderError) Error() string {
+	return fmt.Sprintf("View(%s) failed to render: %v", r.UID, r.Reason)
+}
+
+// Unwrap returns the reason of the failed render if its an error.
+func (r *RenderError) Unwrap() error {
+	if err, ok := r.Reason.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Failure returns the error of the view's last render if it failed, else nil.
+func (v *View) Failure() error {
+	return v.failure
+}
+
+// Failures returns the error of the view's last render if it failed, along
+// with those of the views nested within it which failed while it rendered.
+func (v *View) Failures() []error {
+	var errs []error
+	if v.failure != nil {
+		errs = append(errs, v.failure)
+	}
+	return append(errs, v.nested...)
+}
+
+// renderSafely renders the view's Renderable, recovering any panic from the
+// render as a RenderError.
+func (v *View) renderSafely(m ...string) (dom trees.Markup, err error) {
+	defer func() {
+		if rc := recover(); rc != nil {
+			dom = nil
+			err = &RenderError{UID: v.uid, Reason: rc}
+		}
+	}()
+
+	return v.rview.Render(m...), nil
+}
+
+// fail records the failed render, reports its error through the view's
+// publisher and returns the markup to be rendered in its place. The fallback
+// of the Renderable is used if it provides one, else the previous render is
+// kept. The previous render is left as the view's live markup so the next
+// successful render reconciles against it.
+func (v *View) fail(err error) trees.Markup {
+	v.failure = err
+	v.SendError(err)
+
+	for p := v.parent; p != nil; p = p.parent {
+		p.nested = append(p.nested, err)
+	}
+
+	fb, ok := v.rview.(Fallbacker)
+	if !ok {
+		if v.liveMarkup != nil {
+			return v.liveMarkup
+		}
+		return elems.Div()
+	}
+
+	dom := fb.Fallback(err)
+	if dom == nil {
+		dom = elems.Div()
+	}
+
+	v.backdoor.M = dom
+	v.backdoor.SwapUID(v.uid)
+	v.backdoor.M = nil
+
+	dom.UseEventManager(v.events)
+	v.events.LoadUpEvents()
+
+	return dom
+}
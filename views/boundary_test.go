@@ -0,0 +1,128 @@
+package views
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type brokenItem struct {
+	broken bool
+}
+
+func (b *brokenItem) Render(m ...string) trees.Markup {
+	if b.broken {
+		panic("bad item")
+	}
+	return elems.Span(elems.Text("+ item"))
+}
+
+type fallbackItem struct {
+	brokenItem
+}
+
+func (f *fallbackItem) Fallback(err error) trees.Markup {
+	return elems.Span(elems.Text("item unavailable"))
+}
+
+func TestRenderBoundary(t *testing.T) {
+	item := &brokenItem{}
+	view := NewView(item)
+
+	var reported error
+	view.React(func(r pub.Publisher, err error, _ interface{}) {
+		if err != nil {
+			reported = err
+		}
+	}, true)
+
+	first := view.Render()
+
+	item.broken = true
+	kept := view.Render()
+
+	if kept != first {
+		fatalFailed(t, "Expected the previous render to be kept after a failed render")
+	}
+
+	var re *RenderError
+	if !errors.As(view.Failure(), &re) || re.Reason != "bad item" {
+		fatalFailed(t, "Expected a RenderError for the failed render but got %v", view.Failure())
+	}
+
+	if reported != view.Failure() {
+		fatalFailed(t, "Expected the failed render to be reported to subscribers but got %v", reported)
+	}
+
+	logPassed(t, "Successfully kept previous render and reported the failure")
+
+	item.broken = false
+	view.Render()
+
+	if view.Failure() != nil {
+		fatalFailed(t, "Expected failure to be cleared after a successful render but got %v", view.Failure())
+	}
+
+	logPassed(t, "Successfully recovered after a failed render")
+}
+
+func TestRenderFallback(t *testing.T) {
+	view := NewView(&fallbackItem{brokenItem{broken: true}})
+
+	out := string(view.RenderHTML())
+	if !strings.Contains(out, "item unavailable") {
+		fatalFailed(t, "Expected fallback markup to be rendered but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered fallback markup")
+
+	rec := httptest.NewRecorder()
+	ServeView(rec, httptest.NewRequest("GET", "/items", nil), view)
+
+	if rec.Code != http.StatusInternalServerError {
+		fatalFailed(t, "Expected status %d but got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	if !strings.Contains(rec.Body.String(), "item unavailable") {
+		fatalFailed(t, "Expected fallback markup in response but got %q", rec.Body.String())
+	}
+
+	logPassed(t, "Successfully served fallback markup with a 500 status")
+}
+
+func TestServeNestedFailure(t *testing.T) {
+	broken := NewView(&fallbackItem{brokenItem{broken: true}})
+	page := NewView(Sequence(SequenceMeta{}, NewView(item("Book")), broken))
+
+	rec := httptest.NewRecorder()
+	ServeView(rec, httptest.NewRequest("GET", "/items", nil), page)
+
+	if rec.Code != http.StatusInternalServerError {
+		fatalFailed(t, "Expected status %d for a failed nested view but got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	if body := rec.Body.String(); !strings.Contains(body, "item unavailable") || !strings.Contains(body, "Book") {
+		fatalFailed(t, "Expected the page with the nested fallback but got %q", body)
+	}
+
+	if errs := page.Failures(); len(errs) != 1 || errs[0] != broken.Failure() {
+		fatalFailed(t, "Expected the nested failure to be collected but got %v", errs)
+	}
+
+	logPassed(t, "Successfully served nested failure with a 500 status")
+
+	broken.rview.(*fallbackItem).broken = false
+	page.Render()
+
+	if len(page.Failures()) != 0 {
+		fatalFailed(t, "Expected failures to clear once the nested view renders but got %v", page.Failures())
+	}
+
+	logPassed(t, "Successfully cleared nested failures")
+}
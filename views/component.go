@@ -0,0 +1,82 @@
+package views
+
+import (
+	"sync"
+
+	"github.com/influx6/haiku/trees"
+)
+
+// ComponentRender defines the function type used by a Component to render its
+// markup from its state and props.
+type ComponentRender[S any] func(c *Component[S], m ...string) trees.Markup
+
+// Component provides a View which holds a typed state. Changes made through
+// SetState re-render only the component, patching its markup in place within
+// the closest mounted view, while its props are supplied by the parent's
+// render through UseProps.
+type Component[S any] struct {
+	*View
+	ro     sync.RWMutex
+	state  S
+	props  interface{}
+	render ComponentRender[S]
+}
+
+// NewComponent returns a new Component with the initial state and render
+// function.
+func NewComponent[S any](state S, render ComponentRender[S]) *Component[S] {
+	c := &Component[S]{
+		state:  state,
+		render: render,
+	}
+
+	c.View = NewView(&componentRenderer[S]{c})
+	return c
+}
+
+// State returns a copy of the component's current state.
+func (c *Component[S]) State() S {
+	c.ro.RLock()
+	defer c.ro.RUnlock()
+	return c.state
+}
+
+// SetState updates the component's state using the function and schedules a
+// render of only this component.
+func (c *Component[S]) SetState(fx func(*S)) {
+	c.ro.Lock()
+	fx(&c.state)
+	c.ro.Unlock()
+
+	c.scheduler.Schedule(c.View)
+}
+
+// Props returns the props last supplied to the component.
+func (c *Component[S]) Props() interface{} {
+	c.ro.RLock()
+	defer c.ro.RUnlock()
+	return c.props
+}
+
+// UseProps sets the props the component renders with, it returns the
+// component to allow use within the parent's render
+// e.g `child.UseProps(item).Render(m...)`.
+func (c *Component[S]) UseProps(props interface{}) *Component[S] {
+	c.ro.Lock()
+	c.props = props
+	c.ro.Unlock()
+	return c
+}
+
+// componentRenderer provides the Renderable used by a Component's view.
+type componentRenderer[S any] struct {
+	c *Component[S]
+}
+
+// Render renders the component using its render function.
+func (r *componentRenderer[S]) Render(m ...string) trees.Markup {
+	if r.c.render == nil {
+		return nil
+	}
+	return r.c.render(r.c, m...)
+}
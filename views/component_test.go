@@ -0,0 +1,120 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type counter struct {
+	Count int
+}
+
+// renderCounter counts the renders of the view holding it.
+type renderCounter struct {
+	renders int
+}
+
+func (r *renderCounter) Render(m ...string) trees.Markup {
+	r.renders++
+	return elems.Span(elems.Text(fmt.Sprintf("render %d", r.renders)))
+}
+
+// renderScheduler batches views through a BatchScheduler, rendering them on
+// flush as a mounted view would to patch its dom.
+type renderScheduler struct {
+	batch   *BatchScheduler
+	flushes map[Schedulable]*renderFlush
+}
+
+type renderFlush struct {
+	*View
+	flushed int
+}
+
+func (r *renderFlush) Flush() {
+	r.flushed++
+	r.View.Render()
+}
+
+func (r *renderScheduler) Schedule(s Schedulable) {
+	if r.flushes[s] == nil {
+		r.flushes[s] = &renderFlush{View: s.(*View)}
+	}
+	r.batch.Schedule(r.flushes[s])
+}
+
+func TestComponentSetState(t *testing.T) {
+	clock := NewManualClock()
+
+	parent := NewView(Sequence(SequenceMeta{}))
+	scheduler := &renderScheduler{batch: NewBatchScheduler(clock), flushes: make(map[Schedulable]*renderFlush)}
+	parent.UseScheduler(scheduler)
+
+	var renders, siblingRenders int
+
+	comp := NewComponent(counter{}, func(c *Component[counter], m ...string) trees.Markup {
+		renders++
+		return elems.Span(elems.Text(fmt.Sprintf("%v: %d", c.Props(), c.State().Count)))
+	})
+
+	sibling := NewComponent(counter{}, func(c *Component[counter], m ...string) trees.Markup {
+		siblingRenders++
+		return elems.Span(elems.Text(fmt.Sprintf("sibling: %d", c.State().Count)))
+	})
+
+	probe := &renderCounter{}
+
+	parent.rview.(*SequenceRenderer).Add(probe, comp, sibling)
+	parent.Render()
+
+	if probe.renders != 1 || renders != 1 || siblingRenders != 1 {
+		fatalFailed(t, "Expected parent to render itself and its children once but got %d, %d and %d", probe.renders, renders, siblingRenders)
+	}
+
+	if comp.Depth() != 1 {
+		fatalFailed(t, "Expected component to be nested within parent but got depth %d", comp.Depth())
+	}
+
+	comp.UseProps("clicks")
+	comp.SetState(func(s *counter) { s.Count++ })
+	comp.SetState(func(s *counter) { s.Count++ })
+
+	if comp.State().Count != 2 {
+		fatalFailed(t, "Expected count of %d but got %d", 2, comp.State().Count)
+	}
+
+	if clock.Pending() != 1 {
+		fatalFailed(t, "Expected a single render request but got %d", clock.Pending())
+	}
+
+	if renders != 1 {
+		fatalFailed(t, "Expected no render before the tick but got %d renders", renders-1)
+	}
+
+	clock.Tick()
+
+	if renders != 2 {
+		fatalFailed(t, "Expected state changes within a tick to render once but got %d renders", renders-1)
+	}
+
+	if probe.renders != 1 || siblingRenders != 1 {
+		fatalFailed(t, "Expected only the component to render but parent rendered %d and sibling %d times", probe.renders-1, siblingRenders-1)
+	}
+
+	if len(scheduler.flushes) != 1 || scheduler.flushes[comp.View] == nil || scheduler.flushes[comp.View].flushed != 1 {
+		fatalFailed(t, "Expected only the component to be flushed once but got %d scheduled views", len(scheduler.flushes))
+	}
+
+	logPassed(t, "Successfully rendered only the component once per tick")
+
+	out := string(parent.RenderHTML())
+	if !strings.Contains(out, "clicks: 2") {
+		fatalFailed(t, "Expected %q in rendered output but got %q", "clicks: 2", out)
+	}
+
+	logPassed(t, "Successfully rendered component with state and props")
+}
@@ -0,0 +1,151 @@
+package views
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Context provides values shared down a view hierarchy, where every view has
+// its own Context whose parent is the Context of the view it's nested in.
+// Values are provided and looked up by their type using Provide and Use.
+type Context struct {
+	ro     sync.RWMutex
+	owner  *View
+	parent *Context
+	values map[reflect.Type]*provision
+
+	// used holds the provisions the owner consumed and the Context of each.
+	used map[*provision]*Context
+}
+
+// provision provides a value within a Context and the views which used it.
+type provision struct {
+	value     interface{}
+	consumers map[*View]bool
+}
+
+// Contextual defines a Renderable which wants the Context of its view.
+type Contextual interface {
+	UseContext(*Context)
+}
+
+// newContext returns a new Context owned by the view.
+func newContext(owner *View) *Context {
+	return &Context{
+		owner:  owner,
+		values: make(map[reflect.Type]*provision),
+		used:   make(map[*provision]*Context),
+	}
+}
+
+// Context returns the view's Context.
+func (v *View) Context() *Context {
+	return v.ctx
+}
+
+// Context returns the Context of the view owning the sequence, else nil.
+func (s *SequenceRenderer) Context() *Context {
+	if s.owner == nil {
+		return nil
+	}
+	return s.owner.ctx
+}
+
+// UseContext passes the Context down to the Contextual Renderables of the
+// sequence.
+func (s *SequenceRenderer) UseContext(ctx *Context) {
+	for _, rm := range s.stack {
+		if cx, ok := rm.(Contextual); ok {
+			cx.UseContext(ctx)
+		}
+	}
+}
+
+// setParent sets the Context the lookups continue to when a value is not
+// provided within this one.
+func (c *Context) setParent(p *Context) {
+	c.ro.Lock()
+	c.parent = p
+	c.ro.Unlock()
+}
+
+// Provide provides the value within the Context for it and its descendants,
+// scheduling a render of the views which used a previous value.
+func Provide[T any](ctx *Context, value T) {
+	key := typeKey[T]()
+
+	ctx.ro.Lock()
+	pv, ok := ctx.values[key]
+	if !ok {
+		pv = &provision{consumers: make(map[*View]bool)}
+		ctx.values[key] = pv
+	}
+
+	pv.value = value
+
+	consumers := make([]*View, 0, len(pv.consumers))
+	for cv := range pv.consumers {
+		consumers = append(consumers, cv)
+	}
+	ctx.ro.Unlock()
+
+	for _, cv := range consumers {
+		cv.scheduler.Schedule(cv)
+	}
+}
+
+// Use returns the value of type T provided within the Context or its closest
+// ancestor, registering the Context's view to be rendered again when the
+// value changes till it's unmounted. It returns false if no value was
+// provided.
+func Use[T any](ctx *Context) (T, bool) {
+	key := typeKey[T]()
+
+	for cx := ctx; cx != nil; {
+		cx.ro.Lock()
+		pv, ok := cx.values[key]
+		if ok {
+			if ctx.owner != nil {
+				pv.consumers[ctx.owner] = true
+			}
+
+			value, _ := pv.value.(T)
+			cx.ro.Unlock()
+
+			if ctx.owner != nil {
+				ctx.ro.Lock()
+				ctx.used[pv] = cx
+				ctx.ro.Unlock()
+			}
+
+			return value, true
+		}
+
+		next := cx.parent
+		cx.ro.Unlock()
+		cx = next
+	}
+
+	var zero T
+	return zero, false
+}
+
+// release stops the Context's view being rendered again when the values it
+// used change.
+func (c *Context) release() {
+	c.ro.Lock()
+	used := c.used
+	c.used = make(map[*provision]*Context)
+	c.ro.Unlock()
+
+	for pv, cx := range used {
+		cx.ro.Lock()
+		delete(pv.consumers, c.owner)
+		cx.ro.Unlock()
+	}
+}
+
+// typeKey returns the type of T, which is used to key its values.
+func typeKey[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,85 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type theme string
+
+type themed struct {
+	ctx *Context
+}
+
+func (t *themed) UseContext(ctx *Context) {
+	t.ctx = ctx
+}
+
+func (t *themed) Render(m ...string) trees.Markup {
+	th, _ := Use[theme](t.ctx)
+	return elems.Span(elems.Text("theme: " + string(th)))
+}
+
+type scheduleRecord struct {
+	scheduled []Schedulable
+}
+
+func (s *scheduleRecord) Schedule(sc Schedulable) {
+	s.scheduled = append(s.scheduled, sc)
+}
+
+func TestContext(t *testing.T) {
+	consumer := NewView(&themed{})
+	other := NewView(item("Book"))
+
+	app := NewView(Sequence(SequenceMeta{}, other, SequenceView(SequenceMeta{}, consumer)))
+
+	Provide(app.Context(), theme("dark"))
+
+	out := string(app.RenderHTML())
+	if !strings.Contains(out, "theme: dark") {
+		fatalFailed(t, "Expected nested view to use the provided theme but got %q", out)
+	}
+
+	if _, ok := Use[int](consumer.Context()); ok {
+		fatalFailed(t, "Expected no value for a type never provided")
+	}
+
+	logPassed(t, "Successfully used a value provided by an ancestor")
+
+	record := &scheduleRecord{}
+	app.UseScheduler(record)
+
+	Provide(app.Context(), theme("light"))
+
+	if len(record.scheduled) != 1 || record.scheduled[0] != consumer {
+		fatalFailed(t, "Expected only the consuming view to be scheduled but got %d renders", len(record.scheduled))
+	}
+
+	if out := string(app.RenderHTML()); !strings.Contains(out, "theme: light") {
+		fatalFailed(t, "Expected changed theme to be rendered but got %q", out)
+	}
+
+	logPassed(t, "Successfully re-rendered only the consuming view")
+
+	app.OnUnmount()
+
+	record.scheduled = nil
+	Provide(app.Context(), theme("dark"))
+
+	if len(record.scheduled) != 0 {
+		fatalFailed(t, "Expected unmounted consumer not to be scheduled but got %d renders", len(record.scheduled))
+	}
+
+	app.RenderHTML()
+	Provide(app.Context(), theme("light"))
+
+	if len(record.scheduled) != 1 || record.scheduled[0] != consumer {
+		fatalFailed(t, "Expected consumer to be scheduled again once rendered but got %d renders", len(record.scheduled))
+	}
+
+	logPassed(t, "Successfully released the consumers of an unmounted view")
+}
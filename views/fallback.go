@@ -0,0 +1,147 @@
+package views
+
+import (
+	"errors"
+
+	"github.com/influx6/haiku/pub"
+)
+
+// ErrNavigationCancelled is returned when a guard cancels a navigation.
+var ErrNavigationCancelled = errors.New("Navigation Cancelled")
+
+// ErrTooManyRedirects is returned when a navigation is redirected more than
+// MaxRedirects times.
+var ErrTooManyRedirects = errors.New("Too Many Redirects")
+
+// NavigationError provides the details of a failed navigation, sent as an
+// error through the publisher returned by Pages.Errors.
+type NavigationError struct {
+	Spec PathSpec
+	Err  error
+}
+
+// Error returns the reason and location of the failed navigation.
+func (n *NavigationError) Error() string {
+	return n.Err.Error() + ": " + n.Spec.String()
+}
+
+// Unwrap returns the reason for the failed navigation.
+func (n *NavigationError) Unwrap() error {
+	return n.Err
+}
+
+// UseFallback sets the state activated when no other state of the engine
+// matches the address, it captures the points left as the `rest` parameter.
+func (se *StateEngine) UseFallback(s States) States {
+	return se.UseState("*", s)
+}
+
+// Errors returns the publisher the page sends a *NavigationError to for each
+// navigation which fails, either as its address matches no state or as its
+// guards cancel it.
+func (p *Pages) Errors() pub.Publisher {
+	return p.errors
+}
+
+// NotFound sets the view shown in place of the page's views when a navigation
+// matches no state, the view is hidden again by the next navigation which
+// does. The view is not added to the page's states, it has to be rendered
+// within the layout holding them.
+func (p *Pages) NotFound(v Views, opts ...ViewOption) {
+	v.UseHistory(p.HistoryProvider)
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if !v.Active() {
+		v.Hide()
+	}
+
+	p.ro.Lock()
+	p.notFound = v
+	p.ro.Unlock()
+}
+
+// redirectRule provides a legacy address redirected to another.
+type redirectRule struct {
+	from *pattern
+	to   *pattern
+}
+
+// RedirectRoute redirects navigations to the from address to the to address,
+// e.g moving `/profile/:id` to `/users/:id`. Parameters captured by from are
+// used to expand to, while the query is kept. The server Handler responds
+// with a 301 to these.
+func (p *Pages) RedirectRoute(from, to string) {
+	p.ro.Lock()
+	p.rules = append(p.rules, redirectRule{from: compilePattern(from), to: compilePattern(to)})
+	p.ro.Unlock()
+}
+
+// rewrite returns the address of the first redirect rule matching all the
+// points of the PathSpec.
+func (p *Pages) rewrite(ps PathSpec, points []string) (string, bool) {
+	p.ro.Lock()
+	rules := p.rules
+	p.ro.Unlock()
+
+	for _, rule := range rules {
+		params, consumed, _, ok := rule.from.match(points)
+		if !ok || consumed != len(points) {
+			continue
+		}
+
+		path, err := rule.to.expand(params)
+		if err != nil {
+			continue
+		}
+
+		if len(ps.Query) > 0 {
+			path += "?" + ps.Query.Encode()
+		}
+
+		return path, true
+	}
+
+	return "", false
+}
+
+// fail sends the failed navigation to the page's error publisher.
+func (p *Pages) fail(ps PathSpec, err error) {
+	p.errors.SendError(&NavigationError{Spec: ps, Err: err})
+}
+
+// showNotFound hides the page's views in favour of its not found view,
+// returning false if it has none.
+func (p *Pages) showNotFound() bool {
+	p.ro.Lock()
+	nf := p.notFound
+	p.ro.Unlock()
+
+	if nf == nil {
+		return false
+	}
+
+	p.DeactivateAll()
+	nf.Activate()
+	return true
+}
+
+// hideNotFound hides the page's not found view if shown.
+func (p *Pages) hideNotFound() {
+	p.ro.Lock()
+	nf := p.notFound
+	p.ro.Unlock()
+
+	if nf != nil && nf.Active() {
+		nf.Deactivate()
+	}
+}
+
+// hasNotFound returns true if the page has a not found view.
+func (p *Pages) hasNotFound() bool {
+	p.ro.Lock()
+	defer p.ro.Unlock()
+	return p.notFound != nil
+}
@@ -0,0 +1,107 @@
+package views
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/pub"
+)
+
+func TestPagesNotFound(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), URLPathSequencer)
+
+	home := NewView(item("Home"))
+	missing := NewView(item("Missing"))
+
+	pages.AddView("home", home)
+	pages.NotFound(missing)
+
+	var failed []error
+	pages.Errors().React(func(r pub.Publisher, err error, _ interface{}) {
+		failed = append(failed, err)
+	}, true)
+
+	pages.Follow("/home", "")
+	pages.Follow("/hme", "")
+
+	if home.Active() || !missing.Active() {
+		fatalFailed(t, "Expected unmatched address to show the not found view")
+	}
+
+	if len(failed) != 1 || !errors.Is(failed[0], ErrStateNotFound) {
+		fatalFailed(t, "Expected failed navigation to be published but got %v", failed)
+	}
+
+	logPassed(t, "Successfully showed not found view")
+
+	pages.Follow("/home", "")
+
+	if !home.Active() || missing.Active() {
+		fatalFailed(t, "Expected matched address to hide the not found view")
+	}
+
+	logPassed(t, "Successfully hid not found view")
+}
+
+func TestStateEngineFallback(t *testing.T) {
+	engine := NewStateEngine()
+
+	docs := engine.AddState("docs")
+	guide := docs.Engine().AddState("guide")
+	lost := docs.Engine().UseFallback(NewState())
+
+	if err := engine.All(".docs.guide"); err != nil || !guide.Active() || lost.Active() {
+		fatalFailed(t, "Expected matched address to skip the fallback: %v", err)
+	}
+
+	if err := engine.All(".docs.intro.setup"); err != nil || guide.Active() || !lost.Active() {
+		fatalFailed(t, "Expected unmatched address to activate the fallback: %v", err)
+	}
+
+	if rest := lost.Params().Get("rest"); rest != "intro/setup" {
+		fatalFailed(t, "Expected fallback to capture the points left but got %q", rest)
+	}
+
+	logPassed(t, "Successfully activated fallback state")
+}
+
+func TestPagesRedirectRoute(t *testing.T) {
+	pages := Page(URLPathSequencer)
+
+	user := NewView(&profile{})
+	missing := NewView(item("Missing"))
+
+	pages.AddView("/users/:id", user)
+	pages.NotFound(missing)
+	pages.RedirectRoute("/profile/:id", "/users/:id")
+
+	handler := pages.Handler(NewView(Sequence(SequenceMeta{}, user, missing)))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	rec := serve("/profile/7?tab=posts")
+	if loc := rec.Header().Get("Location"); rec.Code != http.StatusMovedPermanently || loc != "/users/7?tab=posts" {
+		fatalFailed(t, "Expected legacy address to be moved but got %d to %q", rec.Code, loc)
+	}
+
+	rec = serve("/profile/a%20b")
+	if loc := rec.Header().Get("Location"); rec.Code != http.StatusMovedPermanently || loc != "/users/a%20b" {
+		fatalFailed(t, "Expected legacy address to be moved with escaped params but got %d to %q", rec.Code, loc)
+	}
+
+	logPassed(t, "Successfully redirected legacy address")
+
+	rec = serve("/nowhere")
+	if body := rec.Body.String(); rec.Code != http.StatusNotFound || !strings.Contains(body, "Missing") {
+		fatalFailed(t, "Expected unmatched address to render the not found view but got %d: %q", rec.Code, body)
+	}
+
+	logPassed(t, "Successfully served not found view")
+}
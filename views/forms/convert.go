@@ -0,0 +1,197 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influx6/haiku/trees"
+)
+
+// ErrNotStruct is returned when a form is created with a value which is not a
+// pointer to a struct.
+var ErrNotStruct = errors.New("forms: target must be a pointer to a struct")
+
+// timeLayouts provides the layouts used to convert the values of date and time
+// inputs, with the first being used to format times.
+var timeLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+	"15:04",
+}
+
+// inputLayouts maps the types of inputs to the layouts of their time values.
+var inputLayouts = map[string]string{
+	"date":           "2006-01-02",
+	"datetime-local": "2006-01-02T15:04",
+	"time":           "15:04",
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// inputLayout returns the layout of the time values of the input's type.
+func inputLayout(e *trees.Element) string {
+	if at, err := trees.GetAttr(e, "type"); err == nil {
+		if layout, ok := inputLayouts[at.Value]; ok {
+			return layout
+		}
+	}
+	return timeLayouts[0]
+}
+
+// lookup returns the struct field at the dotted path within the target e.g
+// `Address.City`, allocating any nil pointers along the path.
+func lookup(target reflect.Value, path string) (reflect.Value, error) {
+	val := target
+
+	for _, name := range strings.Split(path, ".") {
+		for val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("forms: %q is not a struct field path", path)
+		}
+
+		val = val.FieldByName(name)
+		if !val.IsValid() || !val.CanSet() {
+			return reflect.Value{}, fmt.Errorf("forms: unknown field %q", path)
+		}
+	}
+
+	return val, nil
+}
+
+// assign converts the raw input value to the type of the field and sets it.
+func assign(field reflect.Value, raw string) error {
+	if field.Kind() == reflect.Ptr {
+		if raw == "" {
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		}
+
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+
+		return assign(field.Elem(), raw)
+	}
+
+	if field.Type() == timeType {
+		if raw == "" {
+			field.Set(reflect.ValueOf(time.Time{}))
+			return nil
+		}
+
+		for _, layout := range timeLayouts {
+			if tm, err := time.Parse(layout, raw); err == nil {
+				field.Set(reflect.ValueOf(tm))
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%q is not a valid date", raw)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+
+	case reflect.Bool:
+		field.SetBool(checked(raw))
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if raw == "" {
+			field.SetInt(0)
+			return nil
+		}
+
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", raw)
+		}
+		field.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if raw == "" {
+			field.SetUint(0)
+			return nil
+		}
+
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a positive whole number", raw)
+		}
+		field.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		if raw == "" {
+			field.SetFloat(0)
+			return nil
+		}
+
+		n, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		field.SetFloat(n)
+
+	default:
+		return fmt.Errorf("forms: unsupported field type %s", field.Type())
+	}
+
+	return nil
+}
+
+// format returns the field's value as an input value.
+func format(field reflect.Value) string {
+	return formatAs(field, timeLayouts[0])
+}
+
+// formatAs returns the field's value as an input value, formatting times with
+// the layout.
+func formatAs(field reflect.Value, layout string) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		return formatAs(field.Elem(), layout)
+	}
+
+	if field.Type() == timeType {
+		tm := field.Interface().(time.Time)
+		if tm.IsZero() {
+			return ""
+		}
+		return tm.Format(layout)
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		if field.Bool() {
+			return "true"
+		}
+		return ""
+	case reflect.String:
+		return field.String()
+	}
+
+	return fmt.Sprint(field.Interface())
+}
+
+// checked returns true if the raw value of a checkbox is set.
+func checked(raw string) bool {
+	switch strings.ToLower(raw) {
+	case "", "false", "off", "0":
+		return false
+	}
+	return true
+}
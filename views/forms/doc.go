@@ -0,0 +1,4 @@
+// Package forms provides two-way binding of form inputs to the fields of a Go
+// struct with validation, for forms handled in the browser or posted to the
+// server.
+package forms
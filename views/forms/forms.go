@@ -0,0 +1,388 @@
+package forms
+
+import (
+	"html"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/influx6/faux/domevents"
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/attrs"
+	"github.com/influx6/haiku/trees/elems"
+	"github.com/influx6/haiku/trees/events"
+)
+
+// Field provides the state of a field of a form.
+type Field struct {
+	Path       string
+	form       *Form
+	validators []Validator
+	raw        string
+	unparsed   bool
+	err        error
+	dirty      bool
+	touched    bool
+}
+
+// Error returns the error of the field's conversion or validation, else nil.
+func (fl *Field) Error() error {
+	fl.form.ro.RLock()
+	defer fl.form.ro.RUnlock()
+	return fl.err
+}
+
+// Dirty returns true if the field's value was changed by an input.
+func (fl *Field) Dirty() bool {
+	fl.form.ro.RLock()
+	defer fl.form.ro.RUnlock()
+	return fl.dirty
+}
+
+// Touched returns true if the field's input lost focus or the form was
+// submitted.
+func (fl *Field) Touched() bool {
+	fl.form.ro.RLock()
+	defer fl.form.ro.RUnlock()
+	return fl.touched
+}
+
+// Form binds the inputs of a form to the fields of a struct by their dotted
+// path e.g `Address.City`. Form embeds pub.Publisher and sends on every change
+// to its fields, so a view bound to it re-renders its inputs
+// e.g `form.Bind(view, true)`.
+type Form struct {
+	pub.Publisher
+	ro     sync.RWMutex
+	target reflect.Value
+	fields map[string]*Field
+	submit func(*Form)
+}
+
+// New returns a new Form binding to the struct pointed to by the target.
+func New(target interface{}) (*Form, error) {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	fm := Form{
+		Publisher: pub.Identity(),
+		target:    val,
+		fields:    make(map[string]*Field),
+	}
+
+	return &fm, nil
+}
+
+// Field returns the field at the path, adding the validators to it.
+func (f *Form) Field(path string, validators ...Validator) *Field {
+	f.ro.Lock()
+	defer f.ro.Unlock()
+
+	fl := f.field(path)
+	fl.validators = append(fl.validators, validators...)
+	return fl
+}
+
+// field returns the field at the path, creating it if not found. The form's
+// lock must be held.
+func (f *Form) field(path string) *Field {
+	fl, ok := f.fields[path]
+	if !ok {
+		fl = &Field{Path: path, form: f}
+		f.fields[path] = fl
+	}
+	return fl
+}
+
+// Value returns the input value of the field, which is the last input if it
+// could not be converted.
+func (f *Form) Value(path string) string {
+	f.ro.RLock()
+	defer f.ro.RUnlock()
+	return f.value(path, timeLayouts[0])
+}
+
+// value returns the input value of the field, formatting times with the
+// layout. The form's lock must be held.
+func (f *Form) value(path, layout string) string {
+	if fl, ok := f.fields[path]; ok && fl.unparsed {
+		return fl.raw
+	}
+
+	val, err := lookup(f.target, path)
+	if err != nil {
+		return ""
+	}
+
+	return formatAs(val, layout)
+}
+
+// Set converts the input value and sets it on the field, marking the field
+// dirty and validating it. It returns the field's error if any.
+func (f *Form) Set(path string, raw string) error {
+	f.ro.Lock()
+	err := f.set(path, raw)
+	f.ro.Unlock()
+
+	f.Send(true)
+	return err
+}
+
+// set sets the input value on the field. The form's lock must be held.
+func (f *Form) set(path string, raw string) error {
+	fl := f.field(path)
+	fl.dirty = true
+
+	val, err := lookup(f.target, path)
+	if err != nil {
+		fl.err = err
+		return err
+	}
+
+	if err := assign(val, raw); err != nil {
+		fl.raw, fl.unparsed, fl.err = raw, true, err
+		return err
+	}
+
+	fl.raw, fl.unparsed = "", false
+	return f.validate(fl)
+}
+
+// validate runs the validators of the field, storing the first error. The
+// form's lock must be held.
+func (f *Form) validate(fl *Field) error {
+	if fl.unparsed {
+		return fl.err
+	}
+
+	fl.err = nil
+
+	val, err := lookup(f.target, fl.Path)
+	if err != nil {
+		fl.err = err
+		return err
+	}
+
+	var value interface{}
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Ptr {
+		value = val.Interface()
+	}
+
+	for _, vx := range fl.validators {
+		if err := vx(value); err != nil {
+			fl.err = err
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Touch marks the field as touched.
+func (f *Form) Touch(path string) {
+	f.ro.Lock()
+	f.field(path).touched = true
+	f.ro.Unlock()
+
+	f.Send(true)
+}
+
+// Validate validates and touches every field, returning true if all are valid.
+func (f *Form) Validate() bool {
+	f.ro.Lock()
+
+	valid := true
+	for _, fl := range f.fields {
+		fl.touched = true
+		if f.validate(fl) != nil {
+			valid = false
+		}
+	}
+
+	f.ro.Unlock()
+
+	f.Send(true)
+	return valid
+}
+
+// Errors returns the errors of the fields which failed, keyed by their path.
+func (f *Form) Errors() map[string]error {
+	f.ro.RLock()
+	defer f.ro.RUnlock()
+
+	errs := make(map[string]error)
+	for path, fl := range f.fields {
+		if fl.err != nil {
+			errs[path] = fl.err
+		}
+	}
+
+	return errs
+}
+
+// Decode sets the fields from the posted values, where checkbox fields missing
+// from the values are unchecked. It returns true if all fields are valid.
+func (f *Form) Decode(values url.Values) bool {
+	f.ro.Lock()
+
+	paths := make([]string, 0, len(f.fields))
+	for path := range f.fields {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if raw, ok := values[path]; ok && len(raw) > 0 {
+			f.set(path, raw[0])
+			continue
+		}
+
+		if val, err := lookup(f.target, path); err == nil && val.Kind() == reflect.Bool {
+			f.set(path, "")
+		}
+	}
+
+	f.ro.Unlock()
+
+	return f.Validate()
+}
+
+// DecodeRequest decodes the form values of the posted request.
+func (f *Form) DecodeRequest(r *http.Request) (bool, error) {
+	if err := r.ParseForm(); err != nil {
+		return false, err
+	}
+	return f.Decode(r.PostForm), nil
+}
+
+// OnSubmit sets the function called when the form is submitted and valid.
+func (f *Form) OnSubmit(fx func(*Form)) {
+	f.ro.Lock()
+	f.submit = fx
+	f.ro.Unlock()
+}
+
+// Submit validates the form, calling its submit function if valid.
+func (f *Form) Submit() bool {
+	if !f.Validate() {
+		return false
+	}
+
+	f.ro.RLock()
+	fx := f.submit
+	f.ro.RUnlock()
+
+	if fx != nil {
+		fx(f)
+	}
+
+	return true
+}
+
+// Markup returns a form element which posts to the server when rendered on the
+// server, and submits through the form in the browser.
+func (f *Form) Markup(markup ...trees.Appliable) *trees.Element {
+	fm := elems.Form(trees.NewAttr("method", "post"))
+
+	for _, m := range markup {
+		m.Apply(fm)
+	}
+
+	events.Submit(func(ev domevents.Event, _ trees.Markup) {
+		f.Submit()
+	}, "").PreventDefault().Apply(fm)
+
+	return fm
+}
+
+// Input binds the input, select or textarea element to the field at the path,
+// setting its name and current value and updating the field on input. The
+// value is escaped, as markup is printed as is and it can hold submitted
+// input.
+func (f *Form) Input(path string, e *trees.Element) *trees.Element {
+	f.ro.Lock()
+	fl := f.field(path)
+	value := f.value(path, inputLayout(e))
+	invalid := fl.touched && fl.err != nil
+	f.ro.Unlock()
+
+	attrs.Name(path).Apply(e)
+
+	switch {
+	case e.Name() == "select":
+		for _, opt := range trees.ElementsWithTag(e, "option") {
+			if at, err := trees.GetAttr(opt, "value"); err == nil && html.UnescapeString(at.Value) == value {
+				if oe, ok := opt.(*trees.Element); ok {
+					trees.NewAttr("selected", "selected").Apply(oe)
+				}
+			}
+		}
+
+		events.Change(func(ev domevents.Event, _ trees.Markup) {
+			f.Set(path, ev.Target().Get("value").String())
+		}, "").Apply(e)
+
+	case e.Name() == "textarea":
+		e.AddChild(trees.NewText(html.EscapeString(value)))
+
+		events.Input(func(ev domevents.Event, _ trees.Markup) {
+			f.Set(path, ev.Target().Get("value").String())
+		}, "").Apply(e)
+
+	case trees.AttrContains(e, "type", "checkbox"):
+		if checked(value) {
+			attrs.Checked("checked").Apply(e)
+		}
+
+		events.Change(func(ev domevents.Event, _ trees.Markup) {
+			var raw string
+			if ev.Target().Get("checked").Bool() {
+				raw = "true"
+			}
+			f.Set(path, raw)
+		}, "").Apply(e)
+
+	default:
+		attrs.Value(html.EscapeString(value)).Apply(e)
+
+		events.Input(func(ev domevents.Event, _ trees.Markup) {
+			f.Set(path, ev.Target().Get("value").String())
+		}, "").Apply(e)
+	}
+
+	events.Blur(func(ev domevents.Event, _ trees.Markup) {
+		f.Touch(path)
+	}, "").Apply(e)
+
+	if invalid {
+		trees.NewAttr("aria-invalid", "true").Apply(e)
+	}
+
+	return e
+}
+
+// Message returns a span holding the error of the field once touched, the span
+// is always rendered to keep the markup of the form stable. The error is
+// escaped as it can quote submitted input.
+func (f *Form) Message(path string) *trees.Element {
+	f.ro.Lock()
+	fl := f.field(path)
+	err, touched := fl.err, fl.touched
+	f.ro.Unlock()
+
+	msg := elems.Span(attrs.Class("field-error"))
+	if touched && err != nil {
+		msg.AddChild(elems.Text(html.EscapeString(err.Error())))
+	}
+
+	return msg
+}
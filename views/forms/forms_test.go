@@ -0,0 +1,227 @@
+package forms
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influx6/haiku/tests"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/attrs"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type address struct {
+	City string
+}
+
+type signup struct {
+	Name     string
+	Age      int
+	Born     time.Time
+	Agree    bool
+	Address  *address
+	Nickname *string
+}
+
+func newSignup(t *testing.T) (*Form, *signup) {
+	var user signup
+
+	form, err := New(&user)
+	if err != nil {
+		tests.FatalFailed(t, "Expected form to be created: %s", err)
+	}
+
+	form.Field("Name", Required(""), Min(3))
+	form.Field("Age", Min(18), Max(130))
+	form.Field("Born")
+	form.Field("Agree", Required("must be agreed to"))
+	form.Field("Address.City", Pattern(`^[A-Z]`, "must be capitalized"))
+
+	return form, &user
+}
+
+func TestFormDecode(t *testing.T) {
+	form, user := newSignup(t)
+
+	valid := form.Decode(url.Values{
+		"Name":         {"Alex"},
+		"Age":          {"32"},
+		"Born":         {"1990-02-01"},
+		"Agree":        {"on"},
+		"Address.City": {"Lagos"},
+	})
+
+	if !valid {
+		tests.FatalFailed(t, "Expected valid form but got errors %+v", form.Errors())
+	}
+
+	if user.Name != "Alex" || user.Age != 32 || !user.Agree || user.Address.City != "Lagos" {
+		tests.FatalFailed(t, "Expected posted values to be converted into the struct but got %+v", user)
+	}
+
+	if user.Born.Year() != 1990 || form.Value("Born") != "1990-02-01" {
+		tests.FatalFailed(t, "Expected date to be converted but got %s", user.Born)
+	}
+
+	tests.LogPassed(t, "Successfully decoded posted values into the struct")
+
+	valid = form.Decode(url.Values{
+		"Name":         {"Al"},
+		"Age":          {"twelve"},
+		"Address.City": {"lagos"},
+	})
+
+	if valid {
+		tests.FatalFailed(t, "Expected invalid form")
+	}
+
+	errs := form.Errors()
+	for _, path := range []string{"Name", "Age", "Agree", "Address.City"} {
+		if errs[path] == nil {
+			tests.FatalFailed(t, "Expected an error for %q but got %+v", path, errs)
+		}
+	}
+
+	if form.Value("Age") != "twelve" {
+		tests.FatalFailed(t, "Expected unconverted input to be kept but got %q", form.Value("Age"))
+	}
+
+	tests.LogPassed(t, "Successfully reported field errors")
+}
+
+func TestFormMarkup(t *testing.T) {
+	form, _ := newSignup(t)
+
+	if err := form.Set("Name", "Alexandra"); err != nil {
+		tests.FatalFailed(t, "Expected valid name: %s", err)
+	}
+
+	if !form.Field("Name").Dirty() || form.Field("Age").Dirty() {
+		tests.FatalFailed(t, "Expected only the set field to be dirty")
+	}
+
+	form.Set("Agree", "true")
+	form.Set("Age", "4")
+	form.Touch("Age")
+
+	name := form.Input("Name", elems.Input(attrs.Type("text")))
+	agree := form.Input("Agree", elems.Input(attrs.Type("checkbox")))
+	age := form.Input("Age", elems.Input(attrs.Type("number")))
+
+	tests.Truthy(t, "name has its value", trees.AttrContains(name, "value", "Alexandra"))
+	tests.Truthy(t, "checkbox is checked", trees.AttrContains(agree, "checked", "checked"))
+	tests.Truthy(t, "invalid touched input is marked", trees.AttrContains(age, "aria-invalid", "true"))
+
+	out, _ := trees.SimpleMarkupWriter.Write(form.Message("Age"))
+	if !strings.Contains(out, "must be at least 18") {
+		tests.FatalFailed(t, "Expected error message for touched field but got %q", out)
+	}
+
+	var submitted bool
+	form.OnSubmit(func(*Form) { submitted = true })
+
+	if form.Submit() || submitted {
+		tests.FatalFailed(t, "Expected invalid form not to submit")
+	}
+
+	form.Set("Age", "40")
+	form.Set("Address.City", "Abuja")
+
+	if !form.Submit() || !submitted {
+		tests.FatalFailed(t, "Expected valid form to submit but got errors %+v", form.Errors())
+	}
+
+	tests.LogPassed(t, "Successfully bound inputs and submitted the form")
+}
+
+type booking struct {
+	Room  string
+	Start time.Time
+	At    time.Time
+}
+
+func TestFormSelectAndTimes(t *testing.T) {
+	var b booking
+
+	form, err := New(&b)
+	if err != nil {
+		tests.FatalFailed(t, "Expected form to be created: %s", err)
+	}
+
+	options := func() *trees.Element {
+		return elems.Select(
+			elems.Option(attrs.Value("1")),
+			elems.Option(attrs.Value("10")),
+			elems.Option(attrs.Value("21")),
+		)
+	}
+
+	selected := func(sel *trees.Element) []string {
+		var values []string
+		for _, opt := range trees.ElementsWithTag(sel, "option") {
+			if _, err := trees.GetAttr(opt, "selected"); err == nil {
+				at, _ := trees.GetAttr(opt, "value")
+				values = append(values, at.Value)
+			}
+		}
+		return values
+	}
+
+	if got := selected(form.Input("Room", options())); len(got) != 0 {
+		tests.FatalFailed(t, "Expected empty value to select no option but got %q", got)
+	}
+
+	form.Set("Room", "1")
+
+	if got := selected(form.Input("Room", options())); len(got) != 1 || got[0] != "1" {
+		tests.FatalFailed(t, "Expected only the exact option to be selected but got %q", got)
+	}
+
+	tests.LogPassed(t, "Successfully selected the exact option")
+
+	form.Set("Start", "2016-03-04T09:30")
+	form.Set("At", "18:45")
+
+	start := form.Input("Start", elems.Input(attrs.Type("datetime-local")))
+	at := form.Input("At", elems.Input(attrs.Type("time")))
+
+	tests.Truthy(t, "datetime input keeps its time", trees.AttrContains(start, "value", "2016-03-04T09:30"))
+	tests.Truthy(t, "time input keeps its time", trees.AttrContains(at, "value", "18:45"))
+
+	tests.LogPassed(t, "Successfully formatted times by input type")
+}
+
+func TestFormEscapesSubmittedValues(t *testing.T) {
+	form, _ := newSignup(t)
+
+	payload := `"><script>alert(1)</script>`
+
+	form.Decode(url.Values{
+		"Name": {payload},
+		"Age":  {payload},
+	})
+
+	var printed []string
+	for _, m := range []trees.Markup{
+		form.Input("Name", elems.Input(attrs.Type("text"))),
+		form.Input("Age", elems.Input(attrs.Type("number"))),
+		form.Input("Name", elems.TextArea()),
+		form.Message("Age"),
+	} {
+		out, _ := trees.SimpleMarkupWriter.Write(m)
+		printed = append(printed, out)
+	}
+
+	for _, out := range printed {
+		if strings.Contains(out, "<script>") {
+			tests.FatalFailed(t, "Expected submitted markup to be escaped but got %q", out)
+		}
+	}
+
+	tests.Truthy(t, "value keeps the escaped input", strings.Contains(printed[0], `value="&#34;&gt;&lt;script&gt;`))
+	tests.Truthy(t, "message quotes the escaped input", strings.Contains(printed[3], "&lt;script&gt;"))
+
+	tests.LogPassed(t, "Successfully escaped submitted values")
+}
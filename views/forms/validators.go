@@ -0,0 +1,97 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+)
+
+// Validator defines a function which validates the value of a field, the value
+// is the converted value of the field and not its raw input. Any function of
+// this type can be used as a custom validator.
+type Validator func(value interface{}) error
+
+// Required returns a Validator which fails if the field holds its zero value.
+func Required(msg string) Validator {
+	if msg == "" {
+		msg = "is required"
+	}
+
+	return func(value interface{}) error {
+		if value == nil || reflect.ValueOf(value).IsZero() {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
+
+// Pattern returns a Validator which fails if the field's input value does not
+// match the regular expression. Empty values are left to Required.
+func Pattern(expr string, msg string) Validator {
+	rx := regexp.MustCompile(expr)
+
+	if msg == "" {
+		msg = fmt.Sprintf("must match %s", expr)
+	}
+
+	return func(value interface{}) error {
+		if value == nil {
+			return nil
+		}
+
+		raw := format(reflect.ValueOf(value))
+		if raw == "" || rx.MatchString(raw) {
+			return nil
+		}
+		return errors.New(msg)
+	}
+}
+
+// Min returns a Validator which fails if a number is less than the minimum, or
+// a string is shorter than it.
+func Min(min float64) Validator {
+	return func(value interface{}) error {
+		if n, text, ok := measure(value); ok && n < min {
+			if text {
+				return fmt.Errorf("must be at least %v characters", min)
+			}
+			return fmt.Errorf("must be at least %v", min)
+		}
+		return nil
+	}
+}
+
+// Max returns a Validator which fails if a number is more than the maximum, or
+// a string is longer than it.
+func Max(max float64) Validator {
+	return func(value interface{}) error {
+		if n, text, ok := measure(value); ok && n > max {
+			if text {
+				return fmt.Errorf("must be at most %v characters", max)
+			}
+			return fmt.Errorf("must be at most %v", max)
+		}
+		return nil
+	}
+}
+
+// measure returns the number compared by Min and Max for the value, being the
+// length of a string, and false if the value can't be compared.
+func measure(value interface{}) (n float64, text bool, ok bool) {
+	val := reflect.ValueOf(value)
+
+	switch val.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(val.String())), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), false, true
+	}
+
+	return 0, false, false
+}
@@ -0,0 +1,244 @@
+package views
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// MaxRedirects is the most redirects a navigation follows before its
+// cancelled, stopping guards from redirecting each other forever.
+const MaxRedirects = 10
+
+// Navigation provides the details of a navigation passed to its guards.
+type Navigation struct {
+	From      PathSpec
+	To        PathSpec
+	Params    Params
+	Redirects int
+}
+
+// Decision provides the outcome of a guard, allowing, cancelling or
+// redirecting a navigation.
+type Decision struct {
+	cancel    bool
+	redirect  string
+	permanent bool
+}
+
+// Allow returns the Decision letting the navigation continue.
+func Allow() Decision {
+	return Decision{}
+}
+
+// Cancel returns the Decision stopping the navigation, the location is
+// restored to the one navigated from.
+func Cancel() Decision {
+	return Decision{cancel: true}
+}
+
+// Redirect returns the Decision replacing the navigation with one to the
+// path, which is a path or hash as given to HistoryProvider.Go.
+func Redirect(path string) Decision {
+	return Decision{redirect: path}
+}
+
+// Guard defines a function deciding a navigation, it calls decide once it has
+// decided which can be later for an async check.
+type Guard func(nav Navigation, decide func(Decision))
+
+// Use adds guards to the middleware run on every navigation of the page, after
+// the leave guards of the states being left and before the enter guards of
+// the states being entered.
+func (p *Pages) Use(guards ...Guard) {
+	p.ro.Lock()
+	p.middleware = append(p.middleware, guards...)
+	p.ro.Unlock()
+}
+
+// Navigate runs the guards of the navigation to the PathSpec, activating its
+// address once they all allow it. A navigation started before an earlier one
+// was decided replaces it.
+func (p *Pages) Navigate(ps PathSpec) {
+	p.navigate(ps, 0)
+}
+
+// navigate runs the guards of the navigation, counting the redirects which led
+// to it, and carries out their decision.
+func (p *Pages) navigate(ps PathSpec, redirects int) {
+	p.ro.Lock()
+	from, route := p.current, p.route
+	p.ro.Unlock()
+
+	traversed := atomic.LoadInt32(&p.traversing) == 1
+
+	// nothing changes navigating to the current location e.g as its restored.
+	if from.Sequence != "" && from.String() == ps.String() {
+		return
+	}
+
+	p.decide(from, route, ps, redirects, func(d Decision, ps PathSpec, to []States) {
+		switch {
+		case d.redirect != "" && redirects < MaxRedirects:
+			p.redirect(d.redirect, redirects+1)
+		case d.redirect != "":
+			p.fail(ps, ErrTooManyRedirects)
+			p.restore(from, traversed)
+		case d.cancel:
+			p.fail(ps, ErrNavigationCancelled)
+			p.restore(from, traversed)
+		default:
+			p.enter(ps, to)
+		}
+	})
+}
+
+// Location returns the PathSpec of the page's last navigation, along with
+// the route parameters it captured.
+func (p *Pages) Location() PathSpec {
+	p.ro.Lock()
+	defer p.ro.Unlock()
+	return p.current
+}
+
+// enter activates the address of the PathSpec as the page's current one,
+// showing the page's not found view if it matches no state.
+func (p *Pages) enter(ps PathSpec, to []States) error {
+	p.ro.Lock()
+	p.current, p.route = ps, to
+	p.ro.Unlock()
+
+	if err := p.All(ps.Sequence); err != nil {
+		p.fail(ps, err)
+		p.showNotFound()
+		return err
+	}
+
+	p.hideNotFound()
+	return nil
+}
+
+// decide runs the guards of the navigation from the PathSpec and states of
+// route to the PathSpec ps, passing their decision, ps with its route
+// parameters and the states the navigation enters to done. Decisions made
+// after a later navigation started are dropped.
+func (p *Pages) decide(from PathSpec, route []States, ps PathSpec, redirects int, done func(Decision, PathSpec, []States)) {
+	p.ro.Lock()
+	p.navs++
+	id := p.navs
+	middleware := p.middleware
+	p.ro.Unlock()
+
+	var to []States
+	var params Params
+
+	if points, err := p.prepare(ps.Sequence); err == nil {
+		// legacy addresses are redirected before any guard runs.
+		if path, ok := p.rewrite(ps, points); ok {
+			done(Decision{redirect: path, permanent: true}, ps, nil)
+			return
+		}
+
+		to, params, _ = p.resolve(points)
+	}
+
+	ps.Params = params
+
+	var guards []Guard
+
+	// leave the deepest states first
+	for i := len(route) - 1; i >= 0; i-- {
+		if !hasState(to, route[i]) {
+			_, leave := route[i].guards()
+			guards = append(guards, leave...)
+		}
+	}
+
+	guards = append(guards, middleware...)
+
+	for _, so := range to {
+		if !hasState(route, so) {
+			enter, _ := so.guards()
+			guards = append(guards, enter...)
+		}
+	}
+
+	nav := Navigation{From: from, To: ps, Params: params, Redirects: redirects}
+
+	runGuards(nav, guards, func(d Decision) {
+		p.ro.Lock()
+		stale := p.navs != id
+		p.ro.Unlock()
+
+		if !stale {
+			done(d, ps, to)
+		}
+	})
+}
+
+// redirect replaces the location with the path and navigates to it.
+func (p *Pages) redirect(path string, redirects int) {
+	ps := p.Spec(path, "")
+	if p.usingHash {
+		p.ro.Lock()
+		ps = p.Spec(p.current.Path, path)
+		p.ro.Unlock()
+	}
+
+	p.Replace(path)
+	p.navigate(ps, redirects)
+}
+
+// restore puts back the location of the PathSpec after a cancelled
+// navigation. The location is pushed back if the history traversed to the
+// navigation's own, e.g going back, so the entry moved to is kept, else it
+// replaces the navigation's location.
+func (p *Pages) restore(ps PathSpec, traversed bool) {
+	if ps.String() == "" {
+		return
+	}
+
+	url := ps.String()
+	if p.usingHash {
+		url = ps.Hash
+	}
+
+	if traversed {
+		p.backend.Push(url)
+		return
+	}
+
+	p.Replace(url)
+}
+
+// runGuards runs the guards in order till one decides other than to allow
+// the navigation, passing the decision to done. Decisions after a guard's
+// first are ignored.
+func runGuards(nav Navigation, guards []Guard, done func(Decision)) {
+	if len(guards) == 0 {
+		done(Allow())
+		return
+	}
+
+	var once sync.Once
+
+	guards[0](nav, func(d Decision) {
+		once.Do(func() {
+			if d != Allow() {
+				done(d)
+				return
+			}
+
+			runGuards(nav, guards[1:], done)
+		})
+	})
+}
+
+// hasState returns true if the state is within the list.
+func hasState(list []States, s States) bool {
+	for _, so := range list {
+		if so == s {
+			return true
+		}
+	}
+	return false
+}
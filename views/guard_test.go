@@ -0,0 +1,120 @@
+package views
+
+import (
+	"testing"
+)
+
+func TestPagesGuards(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), URLPathSequencer)
+
+	home := NewView(item("Home"))
+	login := NewView(item("Login"))
+	admin := NewView(item("Admin"))
+	editor := NewView(item("Editor"))
+
+	pages.AddView("home", home)
+	pages.AddView("login", login)
+	pages.AddView("admin", admin)
+	pages.AddView("editor", editor)
+
+	var navigations int
+	pages.Use(func(nav Navigation, decide func(Decision)) {
+		navigations++
+		decide(Allow())
+	})
+
+	var authed bool
+	admin.BeforeEnter(func(nav Navigation, decide func(Decision)) {
+		if !authed {
+			decide(Redirect("/login"))
+			return
+		}
+		decide(Allow())
+	})
+
+	pages.Follow("/admin", "")
+
+	if admin.Active() || !login.Active() {
+		fatalFailed(t, "Expected guarded view to redirect to login")
+	}
+
+	logPassed(t, "Successfully redirected navigation")
+
+	var pending func(Decision)
+	editor.BeforeLeave(func(nav Navigation, decide func(Decision)) {
+		pending = decide
+	})
+
+	pages.Follow("/editor", "")
+	pages.Follow("/home", "")
+
+	if !editor.Active() || home.Active() {
+		fatalFailed(t, "Expected navigation to await the leave guard")
+	}
+
+	pending(Cancel())
+
+	if !editor.Active() || home.Active() {
+		fatalFailed(t, "Expected cancelled navigation to keep the current view")
+	}
+
+	logPassed(t, "Successfully cancelled navigation with an async guard")
+
+	authed = true
+	pages.Follow("/admin", "")
+	pending(Allow())
+
+	if !admin.Active() || editor.Active() {
+		fatalFailed(t, "Expected allowed navigation to activate its view")
+	}
+
+	if navigations != 4 {
+		fatalFailed(t, "Expected middleware to run on every navigation but ran %d times", navigations)
+	}
+
+	logPassed(t, "Successfully allowed navigation through guards and middleware")
+}
+
+func TestPagesCancelledBack(t *testing.T) {
+	mem := NewMemoryHistory("/")
+	pages := HistoryPage(mem, URLPathSequencer)
+
+	first := NewView(item("First"))
+	second := NewView(item("Second"))
+
+	pages.AddView("first", first)
+	pages.AddView("second", second)
+
+	leave := Cancel()
+	second.BeforeLeave(func(nav Navigation, decide func(Decision)) {
+		decide(leave)
+	})
+
+	pages.Go("/first")
+	pages.Go("/second")
+
+	mem.Back()
+
+	if !second.Active() || first.Active() {
+		fatalFailed(t, "Expected cancelled back navigation to keep the current view")
+	}
+
+	if mem.Current() != "/second" || mem.Length() != 3 {
+		fatalFailed(t, "Expected history to be back at %q with 3 entries but got %q with %d", "/second", mem.Current(), mem.Length())
+	}
+
+	logPassed(t, "Successfully restored the location of a cancelled back navigation")
+
+	leave = Allow()
+	mem.Back()
+
+	if !first.Active() || second.Active() {
+		fatalFailed(t, "Expected back navigation to activate the previous view")
+	}
+
+	if mem.Current() != "/first" || mem.Length() != 3 {
+		fatalFailed(t, "Expected history to be at %q with 3 entries but got %q with %d", "/first", mem.Current(), mem.Length())
+	}
+
+	logPassed(t, "Successfully went back once the guard allowed it")
+}
@@ -3,6 +3,8 @@ package views
 import (
 	"bytes"
 	"html/template"
+
+	"github.com/influx6/haiku/trees"
 )
 
 // TemplateRenderable defines a basic example of a Renderable
@@ -33,17 +35,22 @@ func (t *TemplateRenderable) Execute(v interface{}) error {
 	return err
 }
 
-// Render renders out the internal cache
-func (t *TemplateRenderable) Render(_ ...string) string {
-	return string(t.cache.Bytes())
+// Render parses the internal cache into markup. It panics if the cache is not
+// valid html, allowing the view's error boundary to handle the failure.
+func (t *TemplateRenderable) Render(_ ...string) trees.Markup {
+	dom, err := ParseMarkup(t.String())
+	if err != nil {
+		panic(err)
+	}
+	return dom
 }
 
-// RenderHTML renders the output from .Render() as safe html unescaped
+// RenderHTML renders out the internal cache as safe html unescaped
 func (t *TemplateRenderable) RenderHTML(_ ...string) template.HTML {
-	return template.HTML(t.Render())
+	return template.HTML(t.String())
 }
 
-// String calls the render function
+// String returns the internal cache
 func (t *TemplateRenderable) String() string {
-	return t.Render()
+	return string(t.cache.Bytes())
 }
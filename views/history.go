@@ -0,0 +1,235 @@
+package views
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Histories defines a history of locations which a HistoryProvider navigates
+// through. Locations are urls made of a path, query and hash, except for the
+// urls given to a HashHistory which make up its hash. Listeners are notified
+// of changes of location not made by Push or Replace, e.g going back.
+type Histories interface {
+	Push(url string)
+	Replace(url string)
+	Back()
+	Forward()
+	Go(n int)
+	Length() int
+	Current() string
+	Listen(func(url string))
+}
+
+// listeners provides the listeners of a Histories.
+type listeners struct {
+	lo  sync.Mutex
+	fns []func(string)
+}
+
+// Listen adds a function called with the new location each time it changes
+// other than by Push or Replace.
+func (l *listeners) Listen(fn func(url string)) {
+	l.lo.Lock()
+	l.fns = append(l.fns, fn)
+	l.lo.Unlock()
+}
+
+// notify calls the listeners with the location.
+func (l *listeners) notify(url string) {
+	l.lo.Lock()
+	fns := l.fns
+	l.lo.Unlock()
+
+	for _, fn := range fns {
+		fn(url)
+	}
+}
+
+// MemoryHistory provides a Histories kept in memory, for use on the server and
+// within tests where there's no browser history.
+type MemoryHistory struct {
+	listeners
+	ro    sync.Mutex
+	stack []string
+	index int
+}
+
+// NewMemoryHistory returns a new MemoryHistory starting at the url.
+func NewMemoryHistory(url string) *MemoryHistory {
+	return &MemoryHistory{stack: []string{url}}
+}
+
+// Push adds the url after the current location, dropping any locations which
+// were gone back from.
+func (m *MemoryHistory) Push(url string) {
+	m.ro.Lock()
+	m.stack = append(m.stack[:m.index+1], url)
+	m.index = len(m.stack) - 1
+	m.ro.Unlock()
+}
+
+// Replace replaces the current location with the url.
+func (m *MemoryHistory) Replace(url string) {
+	m.ro.Lock()
+	m.stack[m.index] = url
+	m.ro.Unlock()
+}
+
+// Back moves to the previous location.
+func (m *MemoryHistory) Back() {
+	m.Go(-1)
+}
+
+// Forward moves to the next location.
+func (m *MemoryHistory) Forward() {
+	m.Go(1)
+}
+
+// Go moves by n locations, nothing happens if there's no location there.
+func (m *MemoryHistory) Go(n int) {
+	m.ro.Lock()
+	to := m.index + n
+	if n == 0 || to < 0 || to >= len(m.stack) {
+		m.ro.Unlock()
+		return
+	}
+
+	m.index = to
+	url := m.stack[to]
+	m.ro.Unlock()
+
+	m.notify(url)
+}
+
+// Length returns the total locations in the history.
+func (m *MemoryHistory) Length() int {
+	m.ro.Lock()
+	defer m.ro.Unlock()
+	return len(m.stack)
+}
+
+// Current returns the current location.
+func (m *MemoryHistory) Current() string {
+	m.ro.Lock()
+	defer m.ro.Unlock()
+	return m.stack[m.index]
+}
+
+// PushStateHistory provides a Histories using the browser's pushState api.
+type PushStateHistory struct {
+	listeners
+}
+
+// NewPushStateHistory returns a new PushStateHistory, else ErrNotSupported if
+// the browser lacks pushState.
+func NewPushStateHistory() (*PushStateHistory, error) {
+	panicBrowserDetect()
+
+	if !BrowserSupportsPushState() {
+		return nil, ErrNotSupported
+	}
+
+	ph := &PushStateHistory{}
+
+	js.Global.Call("addEventListener", "popstate", func() {
+		ph.notify(ph.Current())
+	})
+
+	return ph, nil
+}
+
+// Push adds the url to the browser history.
+func (p *PushStateHistory) Push(url string) {
+	PushDOMState(url)
+}
+
+// Replace replaces the current location of the browser history.
+func (p *PushStateHistory) Replace(url string) {
+	ReplaceDOMState(url)
+}
+
+// Back moves to the previous location.
+func (p *PushStateHistory) Back() {
+	js.Global.Get("history").Call("back")
+}
+
+// Forward moves to the next location.
+func (p *PushStateHistory) Forward() {
+	js.Global.Get("history").Call("forward")
+}
+
+// Go moves by n locations.
+func (p *PushStateHistory) Go(n int) {
+	js.Global.Get("history").Call("go", n)
+}
+
+// Length returns the total locations in the browser history.
+func (p *PushStateHistory) Length() int {
+	return js.Global.Get("history").Get("length").Int()
+}
+
+// Current returns the current location of the browser.
+func (p *PushStateHistory) Current() string {
+	path, hash := GetLocationURL()
+	return path + hash
+}
+
+// HashHistory provides a Histories using the hash of the browser's location,
+// the urls its given make up the hash.
+type HashHistory struct {
+	PushStateHistory
+	ro       sync.Mutex
+	expected string
+}
+
+// NewHashHistory returns a new HashHistory.
+func NewHashHistory() *HashHistory {
+	panicBrowserDetect()
+
+	hh := &HashHistory{}
+
+	js.Global.Call("addEventListener", "hashchange", func() {
+		hash := js.Global.Get("location").Get("hash").String()
+
+		// changes made by Push and Replace aren't notified.
+		hh.ro.Lock()
+		expected := hh.expected == hash
+		hh.expected = ""
+		hh.ro.Unlock()
+
+		if !expected {
+			hh.notify(hh.Current())
+		}
+	})
+
+	return hh
+}
+
+// expect marks the hash as set by the history.
+func (h *HashHistory) expect(url string) {
+	h.ro.Lock()
+	h.expected = "#" + strings.TrimPrefix(url, "#")
+	h.ro.Unlock()
+}
+
+// Push sets the hash to the url.
+func (h *HashHistory) Push(url string) {
+	h.expect(url)
+	SetDOMHash(url)
+}
+
+// Replace replaces the current location with one of the url as its hash.
+func (h *HashHistory) Replace(url string) {
+	h.expect(url)
+	ReplaceDOMHash(url)
+}
+
+// splitHash returns the path with its query and the hash of the url.
+func splitHash(url string) (string, string) {
+	if at := strings.Index(url, "#"); at >= 0 {
+		return url[:at], url[at:]
+	}
+	return url, ""
+}
@@ -0,0 +1,58 @@
+package views
+
+import (
+	"testing"
+)
+
+func TestMemoryHistory(t *testing.T) {
+	backend := NewMemoryHistory("/")
+	pages := HistoryPage(backend, URLPathSequencer)
+
+	home := NewView(item("Home"))
+	about := NewView(item("About"))
+	editor := NewView(item("Editor"))
+
+	pages.AddView("home", home)
+	pages.AddView("about", about)
+	pages.AddView("editor", editor)
+
+	pages.Go("/home")
+	pages.Go("/about")
+
+	if backend.Length() != 3 || backend.Current() != "/about" || !about.Active() || home.Active() {
+		fatalFailed(t, "Expected pushed locations to be navigated to but at %q", backend.Current())
+	}
+
+	pages.Back()
+
+	if backend.Current() != "/home" || !home.Active() || about.Active() {
+		fatalFailed(t, "Expected going back to navigate to %q but at %q", "/home", backend.Current())
+	}
+
+	pages.Forward()
+
+	if !about.Active() {
+		fatalFailed(t, "Expected going forward to navigate to %q", "/about")
+	}
+
+	logPassed(t, "Successfully navigated back and forth through memory history")
+
+	pages.Go("/editor")
+	editor.BeforeLeave(func(nav Navigation, decide func(Decision)) {
+		decide(Cancel())
+	})
+
+	pages.Back()
+
+	if backend.Current() != "/editor" || backend.Length() != 4 || !editor.Active() {
+		fatalFailed(t, "Expected cancelled navigation to restore %q but at %q", "/editor", backend.Current())
+	}
+
+	pages.Go("/home")
+
+	if backend.Current() != "/editor" || backend.Length() != 5 {
+		fatalFailed(t, "Expected cancelled push to be replaced back to %q but at %q", "/editor", backend.Current())
+	}
+
+	logPassed(t, "Successfully restored location of cancelled navigations")
+}
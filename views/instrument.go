@@ -0,0 +1,124 @@
+package views
+
+import (
+	"time"
+
+	"github.com/influx6/haiku/base"
+	"github.com/influx6/haiku/trees"
+)
+
+// RenderMetric provides the measurements of a single render of a view.
+type RenderMetric struct {
+	UID       string
+	Render    time.Duration // time taken by the view's Renderable.
+	Reconcile time.Duration // time taken reconciling against the previous render.
+	Created   int
+	Reused    int
+	Removed   int
+	Binds     int // events bound by the render.
+	Unbinds   int // events unbound by the render.
+}
+
+// PatchMetric provides the measurements of a patch applied to a view's dom.
+type PatchMetric struct {
+	UID      string
+	Ops      int
+	Duration time.Duration
+}
+
+// PrintMetric provides the measurements of a view's markup printed to html.
+type PrintMetric struct {
+	UID      string
+	Bytes    int
+	Duration time.Duration
+}
+
+// Instrument defines a type which receives the measurements of views.
+type Instrument interface {
+	Rendered(RenderMetric)
+	Patched(PatchMetric)
+	Printed(PrintMetric)
+}
+
+// UseInstrument sets the Instrument which receives the measurements of the view
+// and the views nested within it which have none of their own.
+func (v *View) UseInstrument(in Instrument) {
+	v.instrument = in
+}
+
+// instruments returns the Instrument of the view or its closest ancestor which
+// has one, else nil.
+func (v *View) instruments() Instrument {
+	for vm := v; vm != nil; vm = vm.parent {
+		if vm.instrument != nil {
+			return vm.instrument
+		}
+	}
+	return nil
+}
+
+// reconcile reconciles the markup against the view's live markup, reporting
+// the render to the Instrument if any.
+func (v *View) reconcile(dom trees.Markup, in Instrument, rendered time.Duration) {
+	if in == nil {
+		if v.liveMarkup != nil {
+			dom.Reconcile(v.liveMarkup)
+		}
+
+		dom.UseEventManager(v.events)
+		v.events.LoadUpEvents()
+		return
+	}
+
+	before := eventIDs(v.events)
+	_, stat := trees.ReconcileWithStat(dom, v.liveMarkup)
+
+	dom.UseEventManager(v.events)
+	v.events.LoadUpEvents()
+
+	after := eventIDs(v.events)
+
+	metric := RenderMetric{
+		UID:       v.uid,
+		Render:    rendered,
+		Reconcile: stat.Duration,
+		Created:   stat.Created,
+		Reused:    stat.Reused,
+		Removed:   stat.Removed,
+	}
+
+	for id := range after {
+		if !before[id] {
+			metric.Binds++
+		}
+	}
+
+	for id := range before {
+		if !after[id] {
+			metric.Unbinds++
+		}
+	}
+
+	in.Rendered(metric)
+}
+
+// printReporter provides a trees.Instrument reporting the markup printed for a
+// view to the view's Instrument.
+type printReporter struct {
+	uid string
+	in  Instrument
+}
+
+// Printed reports the printed markup as a PrintMetric.
+func (p printReporter) Printed(stat trees.PrintStat) {
+	p.in.Printed(PrintMetric{UID: p.uid, Bytes: stat.Bytes, Duration: stat.Duration})
+}
+
+// eventIDs returns the set of event ids within the manager.
+func eventIDs(em base.EventManagers) map[string]bool {
+	ids := make(map[string]bool)
+	em.EachEvent(func(ev base.EventSubs) {
+		ids[base.GetEventID(ev)] = true
+	})
+	return ids
+}
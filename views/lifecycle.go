@@ -0,0 +1,97 @@
+package views
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/influx6/haiku/trees"
+)
+
+// Mounter defines a Renderable which wants to know when its view is first
+// rendered into a dom.
+type Mounter interface {
+	OnMount(*js.Object)
+}
+
+// Unmounter defines a Renderable which wants to know when its view is removed
+// from its dom.
+type Unmounter interface {
+	OnUnmount()
+}
+
+// Updater defines a Renderable which wants to know when its view's dom was
+// patched, receiving the markup of the previous render.
+type Updater interface {
+	OnUpdate(prev trees.Markup)
+}
+
+// UpdateChecker defines a Renderable which decides if its view should render
+// for the given address, when it returns false the previous render is kept.
+type UpdateChecker interface {
+	ShouldUpdate(addr string) bool
+}
+
+// Shower defines a Renderable which wants to know when its view's state is
+// activated.
+type Shower interface {
+	OnShow()
+}
+
+// Hider defines a Renderable which wants to know when its view's state is
+// deactivated.
+type Hider interface {
+	OnHide()
+}
+
+// OnMount passes the mount hook to the view's Renderable, allowing nested
+// views to be notified when the view containing them is mounted.
+func (v *View) OnMount(dom *js.Object) {
+	if mv, ok := v.rview.(Mounter); ok {
+		mv.OnMount(dom)
+	}
+}
+
+// OnUnmount passes the unmount hook to the view's Renderable, allowing nested
+// views to be notified when the view containing them is unmounted. The view
+// stops consuming the values it used from its Context.
+func (v *View) OnUnmount() {
+	v.ctx.release()
+
+	if mv, ok := v.rview.(Unmounter); ok {
+		mv.OnUnmount()
+	}
+}
+
+// OnMount passes the mount hook to the Renderables in the sequence.
+func (s *SequenceRenderer) OnMount(dom *js.Object) {
+	for _, rm := range s.stack {
+		if mv, ok := rm.(Mounter); ok {
+			mv.OnMount(dom)
+		}
+	}
+}
+
+// OnUnmount passes the unmount hook to the Renderables in the sequence.
+func (s *SequenceRenderer) OnUnmount() {
+	for _, rm := range s.stack {
+		if mv, ok := rm.(Unmounter); ok {
+			mv.OnUnmount()
+		}
+	}
+}
+
+// OnShow passes the show hook to the Renderables in the sequence.
+func (s *SequenceRenderer) OnShow() {
+	for _, rm := range s.stack {
+		if sv, ok := rm.(Shower); ok {
+			sv.OnShow()
+		}
+	}
+}
+
+// OnHide passes the hide hook to the Renderables in the sequence.
+func (s *SequenceRenderer) OnHide() {
+	for _, rm := range s.stack {
+		if hv, ok := rm.(Hider); ok {
+			hv.OnHide()
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package views
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/influx6/haiku/base"
+	"github.com/influx6/haiku/jsutils"
+)
+
+// InterceptLinks catches clicks on the anchors of the document, navigating
+// same-origin links through the page's HistoryProvider instead of loading
+// them. Links opening elsewhere through their target, clicked with a modifier
+// key, marked as a download or opted out with a `data-external` attribute are
+// left to the browser, as are clicks whose default a handler prevented. The
+// clicks are delegated through a single listener on the document, so anchors
+// rendered later are caught too. Unlike the base event managers, which listen
+// while events are captured, the listener runs as clicks bubble up so the
+// handlers of the anchor and its views get to run first.
+func (p *Pages) InterceptLinks() {
+	panicBrowserDetect()
+
+	p.ro.Lock()
+	defer p.ro.Unlock()
+
+	if p.links != nil {
+		return
+	}
+
+	p.links = func(o *js.Object) {
+		p.followLink(&base.EventObject{Object: o})
+	}
+
+	jsutils.GetDocument().Call("addEventListener", "click", p.links, false)
+}
+
+// ReleaseLinks stops the page intercepting clicks on links.
+func (p *Pages) ReleaseLinks() {
+	p.ro.Lock()
+	links := p.links
+	p.links = nil
+	p.ro.Unlock()
+
+	if links != nil {
+		jsutils.GetDocument().Call("removeEventListener", "click", links, false)
+	}
+}
+
+// followLink navigates to the anchor clicked by the event if it can be handled
+// by the page.
+func (p *Pages) followLink(ev base.Event) {
+	anchor := ev.Target().Call("closest", "a")
+	if anchor == nil || anchor == js.Undefined {
+		return
+	}
+
+	core := ev.Core()
+	location := js.Global.Get("location")
+
+	lc := linkClick{
+		origin:    anchor.Get("origin").String(),
+		path:      anchor.Get("pathname").String(),
+		search:    anchor.Get("search").String(),
+		hash:      anchor.Get("hash").String(),
+		target:    anchor.Call("getAttribute", "target").String(),
+		href:      anchor.Call("hasAttribute", "href").Bool(),
+		download:  anchor.Call("hasAttribute", "download").Bool(),
+		external:  anchor.Call("hasAttribute", "data-external").Bool(),
+		prevented: ev.DefaultPrevented(),
+		modified: core.Get("button").Int() != 0 || core.Get("metaKey").Bool() ||
+			core.Get("ctrlKey").Bool() || core.Get("shiftKey").Bool() || core.Get("altKey").Bool(),
+	}
+
+	current := location.Get("pathname").String() + location.Get("search").String()
+
+	url, ok := lc.url(location.Get("origin").String(), current, p.usingHash)
+	if !ok {
+		return
+	}
+
+	ev.PreventDefault()
+	p.Go(url)
+}
+
+// linkClick provides the details of a click on an anchor.
+type linkClick struct {
+	origin, path, search, hash, target string
+
+	href, download, external bool
+
+	// modified is true if the click was made with a modifier key or a button
+	// other than the main one.
+	modified bool
+
+	// prevented is true if the click's default was prevented by a handler.
+	prevented bool
+}
+
+// url returns the url the click navigates to as given to HistoryProvider.Go,
+// returning false if the click should be left to the browser. Links to the
+// hash of the current path and query are left alone unless the page navigates
+// by hash.
+func (l linkClick) url(origin, current string, usingHash bool) (string, bool) {
+	if !l.href || l.download || l.external || l.modified || l.prevented {
+		return "", false
+	}
+
+	if l.target != "" && l.target != "null" && l.target != "_self" {
+		return "", false
+	}
+
+	if l.origin != origin {
+		return "", false
+	}
+
+	samePage := l.path+l.search == current
+
+	if usingHash {
+		if !samePage || l.hash == "" {
+			return "", false
+		}
+		return l.hash, true
+	}
+
+	if samePage && l.hash != "" {
+		return "", false
+	}
+
+	return l.path + l.search + l.hash, true
+}
@@ -0,0 +1,40 @@
+package views
+
+import (
+	"testing"
+)
+
+func TestLinkClick(t *testing.T) {
+	origin := "http://example.com"
+	link := linkClick{origin: origin, path: "/users/7", search: "?tab=posts", href: true}
+
+	cases := []struct {
+		name  string
+		click func(linkClick) linkClick
+		hash  bool
+		url   string
+		ok    bool
+	}{
+		{"same origin", func(l linkClick) linkClick { return l }, false, "/users/7?tab=posts", true},
+		{"other origin", func(l linkClick) linkClick { l.origin = "http://other.com"; return l }, false, "", false},
+		{"new window", func(l linkClick) linkClick { l.target = "_blank"; return l }, false, "", false},
+		{"self target", func(l linkClick) linkClick { l.target = "_self"; return l }, false, "/users/7?tab=posts", true},
+		{"modifier key", func(l linkClick) linkClick { l.modified = true; return l }, false, "", false},
+		{"download", func(l linkClick) linkClick { l.download = true; return l }, false, "", false},
+		{"external", func(l linkClick) linkClick { l.external = true; return l }, false, "", false},
+		{"prevented", func(l linkClick) linkClick { l.prevented = true; return l }, false, "", false},
+		{"no href", func(l linkClick) linkClick { l.href = false; return l }, false, "", false},
+		{"page hash", func(l linkClick) linkClick { l.path, l.search, l.hash = "/", "", "#top"; return l }, false, "", false},
+		{"hash route", func(l linkClick) linkClick { l.path, l.search, l.hash = "/", "", "#/users/7"; return l }, true, "#/users/7", true},
+		{"hash other page", func(l linkClick) linkClick { return l }, true, "", false},
+	}
+
+	for _, c := range cases {
+		url, ok := c.click(link).url(origin, "/", c.hash)
+		if ok != c.ok || url != c.url {
+			fatalFailed(t, "Expected %s link to give %q, %t but got %q, %t", c.name, c.url, c.ok, url, ok)
+		}
+	}
+
+	logPassed(t, "Successfully decided intercepted links")
+}
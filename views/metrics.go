@@ -0,0 +1,239 @@
+package views
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-humble/detect"
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// DefaultWindow is the number of samples kept by a Collector's histograms if
+// none is given.
+const DefaultWindow = 100
+
+// Summary provides the statistics of the samples within a Histogram.
+type Summary struct {
+	Count int64   `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+}
+
+// Histogram provides a rolling window of the last observed samples.
+type Histogram struct {
+	ro      sync.Mutex
+	samples []float64
+	next    int
+	count   int64
+}
+
+// NewHistogram returns a new Histogram keeping the given number of samples.
+func NewHistogram(size int) *Histogram {
+	if size <= 0 {
+		size = DefaultWindow
+	}
+
+	return &Histogram{samples: make([]float64, 0, size)}
+}
+
+// Observe adds the sample to the histogram, dropping the oldest sample if its
+// window is full.
+func (h *Histogram) Observe(val float64) {
+	h.ro.Lock()
+	defer h.ro.Unlock()
+
+	h.count++
+
+	if len(h.samples) < cap(h.samples) {
+		h.samples = append(h.samples, val)
+		return
+	}
+
+	h.samples[h.next] = val
+	h.next = (h.next + 1) % len(h.samples)
+}
+
+// Summary returns the statistics of the samples within the window, with the
+// total of samples ever observed as its count.
+func (h *Histogram) Summary() Summary {
+	h.ro.Lock()
+	sorted := append([]float64(nil), h.samples...)
+	count := h.count
+	h.ro.Unlock()
+
+	sm := Summary{Count: count}
+	if len(sorted) == 0 {
+		return sm
+	}
+
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, val := range sorted {
+		total += val
+	}
+
+	sm.Min = sorted[0]
+	sm.Max = sorted[len(sorted)-1]
+	sm.Mean = total / float64(len(sorted))
+	sm.P50 = percentile(sorted, 0.50)
+	sm.P90 = percentile(sorted, 0.90)
+	sm.P99 = percentile(sorted, 0.99)
+
+	return sm
+}
+
+// percentile returns the sample at the given rank of the sorted samples.
+func percentile(sorted []float64, rank float64) float64 {
+	ind := int(math.Ceil(rank*float64(len(sorted)))) - 1
+	if ind < 0 {
+		ind = 0
+	}
+	return sorted[ind]
+}
+
+// Collector provides an Instrument which keeps rolling histograms of the
+// measurements of every view, keyed by the view's uid. Durations are kept in
+// milliseconds. A Collector can be published with expvar as its String
+// returns its summaries as json, and served directly as a http.Handler.
+type Collector struct {
+	window int
+	ro     sync.Mutex
+	views  map[string]map[string]*Histogram
+}
+
+// NewCollector returns a new Collector whose histograms keep the given number
+// of samples.
+func NewCollector(window int) *Collector {
+	return &Collector{
+		window: window,
+		views:  make(map[string]map[string]*Histogram),
+	}
+}
+
+// Rendered records the measurements of a view's render.
+func (c *Collector) Rendered(m RenderMetric) {
+	c.observe(m.UID, "render_ms", millis(m.Render))
+	c.observe(m.UID, "reconcile_ms", millis(m.Reconcile))
+	c.observe(m.UID, "created", float64(m.Created))
+	c.observe(m.UID, "reused", float64(m.Reused))
+	c.observe(m.UID, "removed", float64(m.Removed))
+	c.observe(m.UID, "binds", float64(m.Binds))
+	c.observe(m.UID, "unbinds", float64(m.Unbinds))
+}
+
+// Patched records the measurements of a patch to a view's dom.
+func (c *Collector) Patched(m PatchMetric) {
+	c.observe(m.UID, "patch_ms", millis(m.Duration))
+	c.observe(m.UID, "ops", float64(m.Ops))
+}
+
+// Printed records the measurements of a view printed to html.
+func (c *Collector) Printed(m PrintMetric) {
+	c.observe(m.UID, "print_ms", millis(m.Duration))
+	c.observe(m.UID, "bytes", float64(m.Bytes))
+}
+
+// observe adds the sample to the named histogram of the view.
+func (c *Collector) observe(uid, name string, val float64) {
+	c.ro.Lock()
+	hs, ok := c.views[uid]
+	if !ok {
+		hs = make(map[string]*Histogram)
+		c.views[uid] = hs
+	}
+
+	h, ok := hs[name]
+	if !ok {
+		h = NewHistogram(c.window)
+		hs[name] = h
+	}
+	c.ro.Unlock()
+
+	h.Observe(val)
+}
+
+// Summaries returns the summaries of the histograms of every view.
+func (c *Collector) Summaries() map[string]map[string]Summary {
+	c.ro.Lock()
+	defer c.ro.Unlock()
+
+	sums := make(map[string]map[string]Summary, len(c.views))
+	for uid, hs := range c.views {
+		vs := make(map[string]Summary, len(hs))
+		for name, h := range hs {
+			vs[name] = h.Summary()
+		}
+		sums[uid] = vs
+	}
+
+	return sums
+}
+
+// String returns the summaries as json, meeting the expvar.Var interface.
+func (c *Collector) String() string {
+	data, err := json.Marshal(c.Summaries())
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// ServeHTTP writes the summaries as json.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write([]byte(c.String()))
+}
+
+// Table prints the summaries to the browser's console using console.table,
+// with a row per measurement of every view.
+func (c *Collector) Table() {
+	if !detect.IsBrowser() {
+		return
+	}
+
+	sums := c.Summaries()
+
+	uids := make([]string, 0, len(sums))
+	for uid := range sums {
+		uids = append(uids, uid)
+	}
+	sort.Strings(uids)
+
+	var rows []map[string]interface{}
+	for _, uid := range uids {
+		names := make([]string, 0, len(sums[uid]))
+		for name := range sums[uid] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			sm := sums[uid][name]
+			rows = append(rows, map[string]interface{}{
+				"view":   uid,
+				"metric": name,
+				"count":  sm.Count,
+				"mean":   sm.Mean,
+				"p50":    sm.P50,
+				"p90":    sm.P90,
+				"max":    sm.Max,
+			})
+		}
+	}
+
+	js.Global.Get("console").Call("table", rows)
+}
+
+// millis returns the duration in milliseconds.
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
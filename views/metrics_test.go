@@ -0,0 +1,60 @@
+package views
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram(4)
+
+	for i := 1; i <= 6; i++ {
+		h.Observe(float64(i))
+	}
+
+	sm := h.Summary()
+
+	if sm.Count != 6 {
+		fatalFailed(t, "Expected count of %d but got %d", 6, sm.Count)
+	}
+
+	if sm.Min != 3 || sm.Max != 6 || sm.Mean != 4.5 {
+		fatalFailed(t, "Expected window of the last 4 samples but got %+v", sm)
+	}
+
+	logPassed(t, "Successfully kept rolling window of samples")
+}
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector(DefaultWindow)
+
+	seq := Sequence(SequenceMeta{}, NewView(item("Book")))
+	view := NewView(seq)
+	view.UseInstrument(collector)
+
+	view.RenderHTML()
+	view.RenderHTML()
+
+	inner := seq.stack[0].(*View)
+
+	sums := collector.Summaries()
+
+	if sums[view.uid]["render_ms"].Count != 2 {
+		fatalFailed(t, "Expected %d renders recorded but got %+v", 2, sums[view.uid])
+	}
+
+	if sums[inner.uid]["reused"].Count != 2 {
+		fatalFailed(t, "Expected nested view renders to be recorded but got %+v", sums[inner.uid])
+	}
+
+	if sums[view.uid]["bytes"].Max <= 0 {
+		fatalFailed(t, "Expected printed bytes to be recorded but got %+v", sums[view.uid]["bytes"])
+	}
+
+	var decoded map[string]map[string]Summary
+	if err := json.Unmarshal([]byte(collector.String()), &decoded); err != nil {
+		fatalFailed(t, "Expected collector to produce valid json: %s", err)
+	}
+
+	logPassed(t, "Successfully collected view render metrics")
+}
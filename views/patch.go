@@ -2,10 +2,12 @@ package views
 
 import (
 	"fmt"
+	"html"
 	"strings"
 
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/influx6/haiku/jsutils"
+	"github.com/influx6/haiku/trees"
 )
 
 // CreateFragment returns a DocumentFragment with the given html dom
@@ -278,3 +280,164 @@ func allEmpty(s ...string) bool {
 
 	return state
 }
+
+// CreateNode builds the live dom node for the given markup, skipping any
+// children marked as removed.
+func CreateNode(m trees.Markup) *js.Object {
+	if m.Name() == "text" {
+		return createText(m.TextContent())
+	}
+
+	node := jsutils.CreateElement(m.Name())
+
+	jsutils.SetAttribute(node, "hash", m.Hash())
+	jsutils.SetAttribute(node, "uid", m.UID())
+
+	for _, attr := range m.Attributes() {
+		jsutils.SetAttribute(node, attr.Name, textValue(attr.Value))
+	}
+
+	for _, style := range m.Styles() {
+		jsutils.SetStyle(node, style.Name, textValue(style.Value))
+	}
+
+	for _, ch := range m.Children() {
+		if ch.Removed() {
+			continue
+		}
+
+		jsutils.AppendChild(node, CreateNode(ch))
+	}
+
+	return node
+}
+
+// createText returns the dom node for the content of a text markup. Texts hold
+// html as the printer writes them out as is, so their entities are decoded and
+// markup within them is built into a DocumentFragment, matching the first
+// render of the text.
+func createText(text string) *js.Object {
+	if textHasMarkup(text) {
+		return CreateFragment(text)
+	}
+
+	return jsutils.CreateTextNode(textValue(text))
+}
+
+// textHasMarkup returns true if the content of a text markup holds elements.
+func textHasMarkup(text string) bool {
+	return strings.Contains(text, "<")
+}
+
+// textValue returns the content of a text markup, or the value of an attribute
+// or style, as the dom shows it with its entities decoded.
+func textValue(text string) string {
+	return html.UnescapeString(text)
+}
+
+// ReplaceDOM builds the dom node for the markup and replaces the node with the
+// same uid within the live element with it, else appends it to the live element.
+func ReplaceDOM(live *js.Object, m trees.Markup) {
+	node := CreateNode(m)
+	target := findUID(live, m.UID())
+
+	if target == nil {
+		jsutils.AppendChild(live, node)
+		return
+	}
+
+	jsutils.ReplaceNode(target.Get("parentNode"), node, target)
+}
+
+// PatchDOM applies the patches produced by trees.Diff to the nodes within the
+// live element, locating each node by its uid. Unlike Patch, it never
+// serializes the markup and leaves untouched nodes (and their focus,
+// selection or playback state) alone.
+func PatchDOM(live *js.Object, patches []trees.Patch) {
+	for _, patch := range patches {
+		target := findUID(live, patch.UID)
+
+		if target == nil {
+			continue
+		}
+
+		switch patch.Op {
+		case trees.PatchReplace:
+			jsutils.ReplaceNode(target.Get("parentNode"), CreateNode(patch.Markup), target)
+
+		case trees.PatchInsert:
+			node := CreateNode(patch.Markup)
+
+			if ref := jsutils.ChildNodeAt(target, patch.Index); ref != nil {
+				jsutils.InsertBefore(target, ref, node)
+				continue
+			}
+
+			jsutils.AppendChild(target, node)
+
+		case trees.PatchMove:
+			node := jsutils.ChildNodeAt(target, patch.From)
+			if node == nil {
+				continue
+			}
+
+			jsutils.InsertBefore(target, jsutils.ChildNodeAt(target, patch.Index), node)
+
+		case trees.PatchRemove:
+			if node := jsutils.ChildNodeAt(target, patch.Index); node != nil {
+				target.Call("removeChild", node)
+			}
+
+		case trees.PatchText:
+			node := jsutils.ChildNodeAt(target, patch.Index)
+			if node == nil {
+				continue
+			}
+
+			if textHasMarkup(patch.Value) {
+				jsutils.ReplaceNode(target, createText(patch.Value), node)
+				continue
+			}
+
+			node.Set("nodeValue", textValue(patch.Value))
+
+		case trees.PatchSetAttr:
+			jsutils.SetAttribute(target, patch.Name, textValue(patch.Value))
+
+			// form controls only reflect these attributes until the user edits them
+			switch patch.Name {
+			case "value":
+				target.Set("value", patch.Value)
+			case "checked":
+				target.Set("checked", true)
+			}
+
+		case trees.PatchRemoveAttr:
+			jsutils.RemoveAttribute(target, patch.Name)
+
+			if patch.Name == "checked" {
+				target.Set("checked", false)
+			}
+
+		case trees.PatchSetStyle:
+			jsutils.SetStyle(target, patch.Name, textValue(patch.Value))
+
+		case trees.PatchRemoveStyle:
+			jsutils.RemoveStyle(target, patch.Name)
+		}
+	}
+}
+
+// findUID returns the live element or its descendant with the given uid else nil
+func findUID(live *js.Object, uid string) *js.Object {
+	if jsutils.HasAttribute(live, "uid") && jsutils.GetAttribute(live, "uid") == uid {
+		return live
+	}
+
+	target := jsutils.QuerySelector(live, fmt.Sprintf("[uid='%s']", uid))
+	if target == nil || target == js.Undefined {
+		return nil
+	}
+
+	return target
+}
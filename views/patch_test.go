@@ -0,0 +1,36 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func TestPatchTextEntities(t *testing.T) {
+	prev := elems.Span(elems.Text("Fish"))
+	next := elems.Span(elems.Text("Fish &amp; Chips&nbsp;&copy;"))
+
+	next.Reconcile(prev)
+
+	var value string
+	for _, patch := range trees.Diff(prev, next) {
+		if patch.Op == trees.PatchText {
+			value = patch.Value
+		}
+	}
+
+	if value != "Fish &amp; Chips&nbsp;&copy;" {
+		fatalFailed(t, "Expected text patch to carry the printed text but got %q", value)
+	}
+
+	if got := textValue(value); got != "Fish & Chips\u00a0\u00a9" || textHasMarkup(value) {
+		fatalFailed(t, "Expected patched text to show as the printed html does but got %q", got)
+	}
+
+	if !textHasMarkup("Buy <b>now</b>") {
+		fatalFailed(t, "Expected text holding elements to be built as markup")
+	}
+
+	logPassed(t, "Successfully decoded patched text as the printer writes it")
+}
@@ -3,41 +3,59 @@ package views
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/go-humble/detect"
 	"github.com/gopherjs/gopherjs/js"
+	"github.com/influx6/haiku/base"
 	"github.com/influx6/haiku/jsutils"
 	"github.com/influx6/haiku/pub"
 )
 
-// PathSpec represent the current path and hash values
+// PathSpec represent the current path and hash values, along with the query
+// parameters of the location and the route parameters captured once the
+// location is navigated to.
 type PathSpec struct {
 	Hash     string
 	Path     string
 	Sequence string
+	Query    url.Values
+	Params   Params
 }
 
-// String returns the hash and path
+// String returns the path, query and hash
 func (p *PathSpec) String() string {
-	return fmt.Sprintf("%s%s", p.Path, p.Hash)
+	if len(p.Query) == 0 {
+		return fmt.Sprintf("%s%s", p.Path, p.Hash)
+	}
+	return fmt.Sprintf("%s?%s%s", p.Path, p.Query.Encode(), p.Hash)
 }
 
 // PathSequencer provides a function to convert either the path/hash into a
 // dot seperated sequence string for use with States.
 type PathSequencer func(path string, hash string) string
 
+// pointEscaper escapes the dots within the segments of a path, so they're kept
+// within their point once the path is sequenced.
+var pointEscaper = strings.NewReplacer("%", "%25", ".", "%2E")
+
+// pointUnescaper reverses the escaping of pointEscaper.
+var pointUnescaper = strings.NewReplacer("%25", "%", "%2E", ".")
+
 // HashSequencer provides a PathSequencer that returns the hash part of a url,
 // as the path sequence.
 func HashSequencer(path, hash string) string {
-	cleanHash := strings.Replace(hash, "#", ".", -1)
+	cleanHash := strings.Replace(pointEscaper.Replace(hash), "#", ".", -1)
 	return strings.Replace(cleanHash, "/", ".", -1)
 }
 
 // URLPathSequencer provides a PathSequencer that returns the path part of a url,
 // as the path sequence.
 func URLPathSequencer(path, hash string) string {
-	return strings.Replace(path, "/", ".", -1)
+	return strings.Replace(pointEscaper.Replace(path), "/", ".", -1)
 }
 
 // PathObserver represent any continouse changing route path by the browser
@@ -45,6 +63,9 @@ type PathObserver struct {
 	pub.Publisher
 	usingHash bool
 	sequencer PathSequencer
+	ro        sync.Mutex
+	current   PathSpec
+	queries   map[string]pub.Publisher
 }
 
 // Path returns a new PathObserver instance
@@ -55,24 +76,53 @@ func Path(ps PathSequencer) *PathObserver {
 	return &PathObserver{
 		Publisher: pub.Identity(),
 		sequencer: ps,
+		queries:   make(map[string]pub.Publisher),
 	}
 }
 
+// Spec creates a PathSpec from the path and hash, any query string within
+// either is parsed into the PathSpec's Query.
+func (p *PathObserver) Spec(path, hash string) PathSpec {
+	query := make(url.Values)
+	path = splitQuery(path, query)
+	hash = splitQuery(hash, query)
+
+	return PathSpec{Hash: hash, Path: path, Query: query, Sequence: p.sequencer(path, hash)}
+}
+
 // Follow creates a Pathspec from the hash and path and sends it
 func (p *PathObserver) Follow(path, hash string) {
-	p.FollowSpec(PathSpec{Hash: hash, Path: path, Sequence: p.sequencer(path, hash)})
+	p.FollowSpec(p.Spec(path, hash))
 }
 
 // FollowSpec just passes down the Pathspec
 func (p *PathObserver) FollowSpec(ps PathSpec) {
+	p.ro.Lock()
+	prev := p.current
+	p.current = ps
+	changed := changedQueries(p.queries, prev.Query, ps.Query)
+	p.ro.Unlock()
+
 	p.Send(ps)
+
+	for qp, values := range changed {
+		qp.Send(values)
+	}
+}
+
+// Current returns the PathSpec last followed.
+func (p *PathObserver) Current() PathSpec {
+	p.ro.Lock()
+	defer p.ro.Unlock()
+	return p.current
 }
 
-// NotifyPage is used to notify a page of
+// NotifyPage is used to notify a page of path changes, which the page
+// navigates to once its guards allow it.
 func (p *PathObserver) NotifyPage(pg *Pages) {
 	p.React(func(r pub.Publisher, _ error, d interface{}) {
 		if ps, ok := d.(PathSpec); ok {
-			pg.All(ps.Sequence)
+			pg.Navigate(ps)
 		}
 	}, true)
 }
@@ -89,15 +139,7 @@ func (p *PathObserver) NotifyPartialPage(pg *Pages) {
 
 // HashChangePath returns a path observer path changes
 func HashChangePath(ps PathSequencer) *PathObserver {
-	panicBrowserDetect()
-	path := Path(ps)
-	path.usingHash = true
-
-	js.Global.Set("onhashchange", func() {
-		path.Follow(GetLocation())
-	})
-
-	return path
+	return NewHistory(NewHashHistory(), ps).PathObserver
 }
 
 //ErrNotSupported is returned when a feature requested is not supported by the environment
@@ -105,47 +147,83 @@ var ErrNotSupported = errors.New("Feature not supported")
 
 // PopStatePath returns a path observer path changes
 func PopStatePath(ps PathSequencer) (*PathObserver, error) {
-	panicBrowserDetect()
-
-	if !BrowserSupportsPushState() {
-		return nil, ErrNotSupported
+	backend, err := NewPushStateHistory()
+	if err != nil {
+		return nil, err
 	}
 
-	path := Path(ps)
-
-	js.Global.Set("onpopstate", func() {
-		path.Follow(GetLocation())
-	})
-
-	return path, nil
+	return NewHistory(backend, ps).PathObserver, nil
 }
 
 // HistoryProvider wraps the PathObserver with methods that allow easy control of
-// client location
+// client location through its Histories backend
 type HistoryProvider struct {
 	*PathObserver
+	backend Histories
+
+	// traversing is set while following a location the backend moved to on
+	// its own, e.g going back.
+	traversing int32
 }
 
 // History returns a new PathObserver and depending on browser support will either use the
-// popState or HashChange
+// popState or HashChange. Outside the browser the locations are kept in memory, starting at
+// the root.
 func History(ps PathSequencer) *HistoryProvider {
-	pop, err := PopStatePath(ps)
+	if !detect.IsBrowser() {
+		return NewHistory(NewMemoryHistory("/"), ps)
+	}
 
-	if err != nil {
-		pop = HashChangePath(ps)
+	if backend, err := NewPushStateHistory(); err == nil {
+		return NewHistory(backend, ps)
 	}
 
-	return &HistoryProvider{pop}
+	return NewHistory(NewHashHistory(), ps)
+}
+
+// NewHistory returns a new HistoryProvider using the backend, its PathObserver follows the
+// locations the backend moves to.
+func NewHistory(backend Histories, ps PathSequencer) *HistoryProvider {
+	path := Path(ps)
+	_, path.usingHash = backend.(*HashHistory)
+
+	h := &HistoryProvider{PathObserver: path, backend: backend}
+
+	backend.Listen(func(url string) {
+		atomic.StoreInt32(&h.traversing, 1)
+		defer atomic.StoreInt32(&h.traversing, 0)
+
+		path.Follow(splitHash(url))
+	})
+
+	return h
+}
+
+// Backend returns the Histories backend of the provider.
+func (h *HistoryProvider) Backend() Histories {
+	return h.backend
 }
 
-// Go changes the path of the current browser location depending on wether its underline
-// observe is hashed based or pushState based,it will use SetDOMHash or PushDOMState appropriately
+// Go adds the path to the history and follows it, which with a hash based backend
+// is set as the hash.
 func (h *HistoryProvider) Go(path string) {
-	if h.usingHash {
-		SetDOMHash(path)
-		return
-	}
-	PushDOMState(path)
+	h.backend.Push(path)
+	h.Follow(splitHash(h.backend.Current()))
+}
+
+// Replace changes the current location without adding to the history or following it.
+func (h *HistoryProvider) Replace(path string) {
+	h.backend.Replace(path)
+}
+
+// Back moves to the previous location of the history.
+func (h *HistoryProvider) Back() {
+	h.backend.Back()
+}
+
+// Forward moves to the next location of the history.
+func (h *HistoryProvider) Forward() {
+	h.backend.Forward()
 }
 
 // ErrBadSelector is used to indicate if the selector returned no result
@@ -158,6 +236,16 @@ type Pages struct {
 	*StateEngine
 	*HistoryProvider
 	// views []Views
+
+	ro         sync.Mutex
+	middleware []Guard
+	current    PathSpec
+	route      []States
+	navs       int
+	errors     pub.Publisher
+	notFound   Views
+	rules      []redirectRule
+	links      base.JSEventMux
 }
 
 // Page returns the new state engine powered page
@@ -165,11 +253,18 @@ func Page(ps PathSequencer) *Pages {
 	return NewPage(History(ps))
 }
 
+// HistoryPage returns the new state engine powered page navigating through the
+// Histories backend e.g a MemoryHistory for tests
+func HistoryPage(backend Histories, ps PathSequencer) *Pages {
+	return NewPage(NewHistory(backend, ps))
+}
+
 // NewPage returns the new state engine powered page
 func NewPage(p *HistoryProvider) *Pages {
 	pg := &Pages{
 		StateEngine:     NewStateEngine(),
 		HistoryProvider: p,
+		errors:          pub.Identity(),
 	}
 
 	p.NotifyPage(pg)
@@ -179,21 +274,32 @@ func NewPage(p *HistoryProvider) *Pages {
 
 // Mount adds a component into the page for handling/managing of visiblity and
 // gets the dom referenced by the selector using QuerySelector and returns an error if selector gave no result
-func (p *Pages) Mount(selector, addr string, v Views) error {
+func (p *Pages) Mount(selector, addr string, v Views, opts ...ViewOption) error {
 	n := jsutils.GetDocument().Call("querySelector", selector)
 
 	if n == nil || n == js.Undefined {
 		return ErrBadSelector
 	}
 
-	p.AddView(addr, v)
+	p.AddView(addr, v, opts...)
 	v.Mount(n)
 	return nil
 }
 
-// AddView adds a view to the page
-func (p *Pages) AddView(addr string, v Views) {
+// AddView adds a view to the page, configured by the options in the order
+// given e.g `HideWith(&UnmountView{})`. The view stays hidden till its address
+// is activated.
+func (p *Pages) AddView(addr string, v Views, opts ...ViewOption) {
 	v.UseHistory(p.HistoryProvider)
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if !v.Active() {
+		v.Hide()
+	}
+
 	p.UseState(addr, v)
 }
 
@@ -211,12 +317,31 @@ func GetLocation() (string, string) {
 	return path, hash
 }
 
+// GetLocationURL returns the path along with its query string and the hash of
+// the browsers location api else panics if not in a browser
+func GetLocationURL() (string, string) {
+	path, hash := GetLocation()
+	return path + js.Global.Get("location").Get("search").String(), hash
+}
+
 // PushDOMState adds a new state the dom push history
 func PushDOMState(path string) {
 	panicBrowserDetect()
 	js.Global.Get("history").Call("pushState", nil, "", path)
 }
 
+// ReplaceDOMState replaces the current state of the dom push history
+func ReplaceDOMState(path string) {
+	panicBrowserDetect()
+	js.Global.Get("history").Call("replaceState", nil, "", path)
+}
+
+// ReplaceDOMHash sets the dom location hash without adding to the history
+func ReplaceDOMHash(hash string) {
+	panicBrowserDetect()
+	js.Global.Get("location").Call("replace", "#"+strings.TrimPrefix(hash, "#"))
+}
+
 // SetDOMHash sets the dom location hash
 func SetDOMHash(hash string) {
 	panicBrowserDetect()
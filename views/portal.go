@@ -0,0 +1,133 @@
+package views
+
+import (
+	"sync"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/influx6/haiku/jsutils"
+	"github.com/influx6/haiku/trees"
+)
+
+// PortalRenderable provides a Renderable which renders its content into the
+// dom node matching its target selector rather than within the view owning
+// it, e.g for modals and toasts which must sit at the end of the body. The
+// content follows the lifecycle of the owning view, being removed when the
+// owner is hidden or unmounted, and its events are managed by an
+// EventManagers attached to the owner's.
+type PortalRenderable struct {
+	target string
+	view   *View
+	ro     sync.Mutex
+	owner  *View
+	dom    *js.Object
+	shown  bool
+}
+
+// Portal returns a new PortalRenderable rendering the content into the dom
+// node matching the target selector once the owning view is mounted.
+func Portal(target string, content Renderable) *PortalRenderable {
+	return &PortalRenderable{
+		target: target,
+		view:   NewView(content),
+		shown:  true,
+	}
+}
+
+// View returns the view rendering the portal's content.
+func (p *PortalRenderable) View() *View {
+	return p.view
+}
+
+// adopt attaches the content's view to the view owning the portal.
+func (p *PortalRenderable) adopt(owner *View) {
+	p.ro.Lock()
+	p.owner = owner
+	p.ro.Unlock()
+
+	p.view.adopt(owner)
+	owner.events.AttachManager(p.view.events)
+}
+
+// UseScheduler sets the Scheduler used by the portal's content.
+func (p *PortalRenderable) UseScheduler(s Scheduler) {
+	p.view.UseScheduler(s)
+}
+
+// Render renders the placeholder of the portal within the owner's markup,
+// requesting a render of the content if its mounted into its target.
+func (p *PortalRenderable) Render(m ...string) trees.Markup {
+	placeholder := trees.NewElement("template", false)
+	trees.NewAttr("portal", p.target).Apply(placeholder)
+
+	p.ro.Lock()
+	mounted := p.dom != nil
+	p.ro.Unlock()
+
+	if mounted {
+		p.view.scheduler.Schedule(p.view)
+	}
+
+	return placeholder
+}
+
+// OnMount mounts the content into the portal's target once the owner is
+// mounted.
+func (p *PortalRenderable) OnMount(_ *js.Object) {
+	p.mount()
+}
+
+// OnUnmount removes the content from the portal's target.
+func (p *PortalRenderable) OnUnmount() {
+	p.unmount()
+}
+
+// OnShow mounts the content into the portal's target again.
+func (p *PortalRenderable) OnShow() {
+	p.ro.Lock()
+	p.shown = true
+	p.ro.Unlock()
+
+	p.mount()
+}
+
+// OnHide removes the content from the portal's target.
+func (p *PortalRenderable) OnHide() {
+	p.ro.Lock()
+	p.shown = false
+	p.ro.Unlock()
+
+	p.unmount()
+}
+
+// mount mounts the content's view into the portal's target if the owner is
+// mounted and shown.
+func (p *PortalRenderable) mount() {
+	p.ro.Lock()
+	if p.dom != nil || !p.shown || p.owner == nil || (p.owner.dom == nil && p.owner.mountedRoot() == nil) {
+		p.ro.Unlock()
+		return
+	}
+
+	target := jsutils.QuerySelector(js.Global.Get("document"), p.target)
+	if target == nil || target == js.Undefined {
+		p.ro.Unlock()
+		return
+	}
+
+	p.dom = target
+	p.ro.Unlock()
+
+	p.view.Mount(target)
+}
+
+// unmount removes the content's view from the portal's target.
+func (p *PortalRenderable) unmount() {
+	p.ro.Lock()
+	mounted := p.dom != nil
+	p.dom = nil
+	p.ro.Unlock()
+
+	if mounted {
+		p.view.Unmount()
+	}
+}
@@ -0,0 +1,35 @@
+package views
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPortal(t *testing.T) {
+	modal := Portal("body", item("Checkout"))
+	owner := NewView(Sequence(SequenceMeta{}, item("Book"), modal))
+
+	if modal.View().parent != owner {
+		fatalFailed(t, "Expected portal content to be owned by the view rendering it")
+	}
+
+	if !owner.events.HasManager(modal.View().events) {
+		fatalFailed(t, "Expected portal content events to be attached to the owner's events")
+	}
+
+	out := string(owner.RenderHTML())
+	if !strings.Contains(out, `portal="body"`) || strings.Contains(out, "Checkout") {
+		fatalFailed(t, "Expected only a placeholder within the owner's markup but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered portal placeholder within its owner")
+
+	owner.Hide()
+	owner.Show()
+
+	if modal.dom != nil {
+		fatalFailed(t, "Expected portal not to mount while its owner is not mounted")
+	}
+
+	logPassed(t, "Successfully kept portal unmounted with its owner")
+}
@@ -0,0 +1,133 @@
+package views
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/influx6/haiku/pub"
+)
+
+// splitQuery removes the query string from the path or hash, adding its
+// parameters into the query.
+func splitQuery(s string, query url.Values) string {
+	at := strings.Index(s, "?")
+	if at < 0 {
+		return s
+	}
+
+	if values, err := url.ParseQuery(s[at+1:]); err == nil {
+		for key, vals := range values {
+			query[key] = append(query[key], vals...)
+		}
+	}
+
+	return s[:at]
+}
+
+// changedQueries returns the publishers of the query keys whose values differ
+// between the queries, along with their new values.
+func changedQueries(queries map[string]pub.Publisher, prev, next url.Values) map[pub.Publisher][]string {
+	changed := make(map[pub.Publisher][]string)
+
+	for key, qp := range queries {
+		if !reflect.DeepEqual(prev[key], next[key]) {
+			changed[qp] = next[key]
+		}
+	}
+
+	return changed
+}
+
+// Query returns a pub.Publisher sending the values of the query parameter as
+// a []string each time they change, e.g binding a view to redraw its list as
+// the `page` parameter changes:
+//
+//	history.Query("page").Bind(view, true)
+func (p *PathObserver) Query(key string) pub.Publisher {
+	p.ro.Lock()
+	defer p.ro.Unlock()
+
+	if qp, ok := p.queries[key]; ok {
+		return qp
+	}
+
+	qp := pub.Identity()
+	p.queries[key] = qp
+	return qp
+}
+
+// URLBuilder provides a builder of a url's path, query parameters and hash,
+// for use with HistoryProvider.GoURL.
+type URLBuilder struct {
+	path  string
+	query url.Values
+	hash  string
+}
+
+// BuildURL returns a new URLBuilder of the path, any query string within the
+// path is parsed into its parameters.
+func BuildURL(path string) *URLBuilder {
+	query := make(url.Values)
+	return &URLBuilder{path: splitQuery(path, query), query: query}
+}
+
+// CurrentURL returns a URLBuilder starting from the location last followed,
+// e.g to move to the next page of a list:
+//
+//	history.GoURL(history.CurrentURL().Set("page", "2"))
+func (h *HistoryProvider) CurrentURL() *URLBuilder {
+	ps := h.Current()
+
+	query := make(url.Values)
+	for key, vals := range ps.Query {
+		query[key] = append([]string(nil), vals...)
+	}
+
+	if h.usingHash {
+		return &URLBuilder{path: strings.TrimPrefix(ps.Hash, "#"), query: query}
+	}
+
+	return &URLBuilder{path: ps.Path, query: query, hash: ps.Hash}
+}
+
+// Set sets the query parameter to the value, replacing any values it had.
+func (u *URLBuilder) Set(key, value string) *URLBuilder {
+	u.query.Set(key, value)
+	return u
+}
+
+// Add adds the value to the query parameter.
+func (u *URLBuilder) Add(key, value string) *URLBuilder {
+	u.query.Add(key, value)
+	return u
+}
+
+// Del removes the query parameter.
+func (u *URLBuilder) Del(key string) *URLBuilder {
+	u.query.Del(key)
+	return u
+}
+
+// Hash sets the hash of the url.
+func (u *URLBuilder) Hash(hash string) *URLBuilder {
+	u.hash = hash
+	if hash != "" && !strings.HasPrefix(hash, "#") {
+		u.hash = "#" + hash
+	}
+	return u
+}
+
+// String returns the url.
+func (u *URLBuilder) String() string {
+	if len(u.query) == 0 {
+		return u.path + u.hash
+	}
+	return u.path + "?" + u.query.Encode() + u.hash
+}
+
+// GoURL changes the location to the url built by the URLBuilder as Go does,
+// when the history is hash based the url's path and query make up the hash.
+func (h *HistoryProvider) GoURL(u *URLBuilder) {
+	h.Go(u.String())
+}
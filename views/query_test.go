@@ -0,0 +1,41 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/influx6/haiku/pub"
+)
+
+func TestPathQuery(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), URLPathSequencer)
+	pages.AddView("/users/:id", NewView(&profile{}))
+
+	var seen []string
+	pages.Query("page").React(func(r pub.Publisher, _ error, d interface{}) {
+		seen = append(seen, d.([]string)...)
+	}, true)
+
+	pages.Follow("/users/3?page=2&sort=name", "")
+
+	ps := pages.Location()
+	if ps.Path != "/users/3" || ps.Query.Get("sort") != "name" || ps.Params.Get("id") != "3" {
+		fatalFailed(t, "Expected path, query and params to be parsed but got %+v", ps)
+	}
+
+	logPassed(t, "Successfully parsed query and route params into the PathSpec")
+
+	pages.Follow("/users/4?page=2", "")
+	pages.Follow(pages.CurrentURL().Set("page", "3").String(), "")
+
+	if len(seen) != 2 || seen[0] != "2" || seen[1] != "3" {
+		fatalFailed(t, "Expected query publisher to send only changes but got %v", seen)
+	}
+
+	logPassed(t, "Successfully notified query parameter changes")
+
+	if url := BuildURL("/users?tab=posts").Set("page", "1").Hash("top").String(); url != "/users?page=1&tab=posts#top" {
+		fatalFailed(t, "Expected built url but got %q", url)
+	}
+
+	logPassed(t, "Successfully built url with query parameters")
+}
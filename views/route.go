@@ -0,0 +1,280 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Params provides the parameters captured by the route patterns of the
+// states along an address, e.g the `id` of `/users/:id`.
+type Params map[string]string
+
+// Has returns true if the parameter was captured.
+func (p Params) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+// Get returns the value of the parameter, else an empty string.
+func (p Params) Get(name string) string {
+	return p[name]
+}
+
+// Int returns the value of the parameter as an int.
+func (p Params) Int(name string) (int, error) {
+	return strconv.Atoi(p[name])
+}
+
+// Float returns the value of the parameter as a float64.
+func (p Params) Float(name string) (float64, error) {
+	return strconv.ParseFloat(p[name], 64)
+}
+
+// Bool returns the value of the parameter as a bool.
+func (p Params) Bool(name string) (bool, error) {
+	return strconv.ParseBool(p[name])
+}
+
+// merge returns the parameters with the captured ones added over them.
+func (p Params) merge(captured Params) Params {
+	if len(captured) == 0 {
+		return p
+	}
+
+	merged := make(Params, len(p)+len(captured))
+	for name, val := range p {
+		merged[name] = val
+	}
+	for name, val := range captured {
+		merged[name] = val
+	}
+	return merged
+}
+
+// Routed defines a Renderable which wants the parameters captured from the
+// address its view was activated with, they're passed in before each render.
+type Routed interface {
+	UseParams(Params)
+}
+
+// segmentKind defines the kinds of segments in a route pattern, in order of
+// increasing specificity.
+type segmentKind int
+
+const (
+	wildcardSegment segmentKind = iota + 1
+	optionalSegment
+	paramSegment
+	literalSegment
+)
+
+// segment provides a single point of a route pattern.
+type segment struct {
+	kind segmentKind
+	name string
+}
+
+// pattern provides a route pattern matching the points of a state address,
+// made of literal points, `:name` parameters, `:name?` optional parameters
+// and a trailing `*name` wildcard capturing the points left.
+type pattern struct {
+	addr     string
+	segments []segment
+}
+
+// compilePattern returns the pattern of a state address, its points can be
+// seperated by either '/' or '.' e.g `/users/:id` and `users.:id` match alike.
+func compilePattern(addr string) *pattern {
+	p := &pattern{addr: addr}
+
+	points := strings.FieldsFunc(addr, func(r rune) bool {
+		return r == '/' || r == '.'
+	})
+
+	for _, point := range points {
+		var sg segment
+
+		switch {
+		case strings.HasPrefix(point, "*"):
+			sg = segment{kind: wildcardSegment, name: strings.TrimPrefix(point, "*")}
+			if sg.name == "" {
+				sg.name = "rest"
+			}
+		case strings.HasPrefix(point, ":") && strings.HasSuffix(point, "?"):
+			sg = segment{kind: optionalSegment, name: strings.Trim(point, ":?")}
+		case strings.HasPrefix(point, ":"):
+			sg = segment{kind: paramSegment, name: strings.TrimPrefix(point, ":")}
+		default:
+			sg = segment{kind: literalSegment, name: point}
+		}
+
+		p.segments = append(p.segments, sg)
+	}
+
+	return p
+}
+
+// unmatchedRank ranks the segments of a pattern which matched no point, e.g an
+// optional parameter left out, below the end of a shorter pattern.
+const unmatchedRank = -1
+
+// match matches the pattern against the start of the points, returning the
+// parameters captured, the total points matched and the rank of each segment,
+// which is its kind if it matched a point else unmatchedRank.
+func (p *pattern) match(points []string) (Params, int, []int, bool) {
+	params := make(Params)
+	ranks := make([]int, len(p.segments))
+
+	var at int
+	for i, sg := range p.segments {
+		ranks[i] = unmatchedRank
+
+		switch sg.kind {
+		case literalSegment:
+			if at >= len(points) || points[at] != sg.name {
+				return nil, 0, nil, false
+			}
+			at++
+		case paramSegment:
+			if at >= len(points) || points[at] == "" {
+				return nil, 0, nil, false
+			}
+			params[sg.name] = points[at]
+			at++
+		case optionalSegment:
+			if at >= len(points) {
+				continue
+			}
+			params[sg.name] = points[at]
+			at++
+		case wildcardSegment:
+			params[sg.name] = strings.Join(points[at:], "/")
+			if at == len(points) {
+				continue
+			}
+			at = len(points)
+		}
+
+		ranks[i] = int(sg.kind)
+	}
+
+	if at == 0 {
+		return nil, 0, nil, false
+	}
+
+	return params, at, ranks, true
+}
+
+// moreSpecific returns true if the segment ranks of a match are more specific
+// than those of another, comparing them segment by segment so a literal beats
+// a parameter, which beats an optional parameter and then a wildcard. Past the
+// end of the shorter ranks, the match whose segments all matched points wins
+// over one with segments left unmatched, and one matching more points wins
+// over the shorter.
+func moreSpecific(a, b []int) bool {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var ra, rb int
+		if i < len(a) {
+			ra = a[i]
+		}
+		if i < len(b) {
+			rb = b[i]
+		}
+
+		if ra != rb {
+			return ra > rb
+		}
+	}
+	return false
+}
+
+// routeMatch provides a state matched by its pattern.
+type routeMatch struct {
+	state    States
+	params   Params
+	consumed int
+	ranks    []int
+	addr     string
+}
+
+// match returns the state whose pattern best matches the start of the points.
+// Patterns are compared segment by segment as moreSpecific does, then by the
+// points they matched.
+func (se *StateEngine) match(points []string) (States, Params, []string) {
+	var matches []routeMatch
+
+	se.rw.RLock()
+	for so, p := range se.patterns {
+		if params, consumed, ranks, ok := p.match(points); ok {
+			matches = append(matches, routeMatch{
+				state:    so,
+				params:   params,
+				consumed: consumed,
+				ranks:    ranks,
+				addr:     p.addr,
+			})
+		}
+	}
+	se.rw.RUnlock()
+
+	if len(matches) == 0 {
+		return nil, nil, nil
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if moreSpecific(matches[i].ranks, matches[j].ranks) {
+			return true
+		}
+		if moreSpecific(matches[j].ranks, matches[i].ranks) {
+			return false
+		}
+		if matches[i].consumed != matches[j].consumed {
+			return matches[i].consumed > matches[j].consumed
+		}
+		return matches[i].addr < matches[j].addr
+	})
+
+	best := matches[0]
+	return best.state, best.params, points[best.consumed:]
+}
+
+// ErrMissingParam is returned when a route pattern is expanded without a
+// value for one of its parameters.
+var ErrMissingParam = errors.New("Route Parameter Missing")
+
+// expand returns the path of the pattern with its parameters replaced by the
+// escaped values of the params, optional parameters and wildcards without a
+// value are left out. Wildcards keep the '/' between their segments.
+func (p *pattern) expand(params Params) (string, error) {
+	var points []string
+
+	for _, sg := range p.segments {
+		switch sg.kind {
+		case literalSegment:
+			points = append(points, sg.name)
+		case paramSegment:
+			if params.Get(sg.name) == "" {
+				return "", fmt.Errorf("%w: %q", ErrMissingParam, sg.name)
+			}
+			points = append(points, url.PathEscape(params.Get(sg.name)))
+		case optionalSegment:
+			if val := params.Get(sg.name); val != "" {
+				points = append(points, url.PathEscape(val))
+			}
+		case wildcardSegment:
+			if val := params.Get(sg.name); val != "" {
+				rest := strings.Split(val, "/")
+				for i, point := range rest {
+					rest[i] = url.PathEscape(point)
+				}
+				points = append(points, strings.Join(rest, "/"))
+			}
+		}
+	}
+
+	return "/" + strings.Join(points, "/"), nil
+}
@@ -0,0 +1,126 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+type profile struct {
+	id int
+}
+
+func (p *profile) UseParams(params Params) {
+	p.id, _ = params.Int("id")
+}
+
+func (p *profile) Render(m ...string) trees.Markup {
+	return elems.Span(elems.Text(fmt.Sprintf("user %d", p.id)))
+}
+
+func TestPagesPatterns(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), nil)
+
+	view := NewView(&profile{})
+	pages.AddView("/users/:id", view)
+
+	if err := pages.All(URLPathSequencer("/users/7/", "")); err != nil {
+		fatalFailed(t, "Unable to activate pattern: %s", err)
+	}
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "user 7") {
+		fatalFailed(t, "Expected view to render with its params but got %q", out)
+	}
+
+	logPassed(t, "Successfully passed route params to the view's Renderable")
+}
+
+func TestPagesURL(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), URLPathSequencer)
+
+	user := NewView(&profile{})
+	posts := NewView(item("Posts"))
+
+	pages.AddView("/users/:id", user, Named("user"))
+	posts.UseName("user.posts")
+	user.Engine().UseState("posts/:page?", posts)
+
+	url, err := pages.URL("user.posts", Params{"id": "7"})
+	if err != nil || url != "/users/7/posts" {
+		fatalFailed(t, "Expected path url of named route but got %q: %v", url, err)
+	}
+
+	files := NewView(item("Files"))
+	pages.AddView("/files/:id/*path", files, Named("file"))
+
+	url, err = pages.URL("file", Params{"id": "a/b?c", "path": "docs/my notes?.md"})
+	if err != nil || url != "/files/a%2Fb%3Fc/docs/my%20notes%3F.md" {
+		fatalFailed(t, "Expected escaped url of named route but got %q: %v", url, err)
+	}
+
+	if _, err := pages.URL("user", nil); !errors.Is(err, ErrMissingParam) {
+		fatalFailed(t, "Expected url without its parameter to fail but got %v", err)
+	}
+
+	if _, err := pages.URL("admin", nil); err != ErrStateNotFound {
+		fatalFailed(t, "Expected url of unknown route to fail but got %v", err)
+	}
+
+	logPassed(t, "Successfully built path urls of named routes")
+
+	link := elems.RouteLink(pages, "user", map[string]string{"id": "3"}, elems.Text("Profile"))
+	if out, _ := trees.SimpleMarkupWriter.Write(link); !strings.Contains(out, `href="/users/3"`) {
+		fatalFailed(t, "Expected link to the named route but got %q", out)
+	}
+
+	logPassed(t, "Successfully linked named route")
+
+	hashed := HistoryPage(NewMemoryHistory("/"), HashSequencer)
+	hashed.AddView("/users/:id", NewView(&profile{}), Named("user"))
+
+	if url, err := hashed.URL("user", Params{"id": "7"}); err != nil || url != "/users/7" {
+		fatalFailed(t, "Expected path url of named route without a hash history but got %q: %v", url, err)
+	}
+
+	// stands in for a HashHistory backend which needs a browser.
+	hashed.usingHash = true
+
+	if url, err := hashed.URL("user", Params{"id": "7"}); err != nil || url != "#/users/7" {
+		fatalFailed(t, "Expected hash url of named route but got %q: %v", url, err)
+	}
+
+	logPassed(t, "Successfully built hash urls of named routes")
+}
+
+func TestPagesDottedParams(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), URLPathSequencer)
+
+	file := NewView(item("File"))
+	pages.AddView("/files/:id", file)
+
+	for _, id := range []string{"report.pdf", "v1.2", "100%.txt"} {
+		pages.Follow("/files/"+id, "")
+
+		if !file.Active() || file.Params().Get("id") != id {
+			fatalFailed(t, "Expected dotted param %q to be captured but got %q", id, file.Params().Get("id"))
+		}
+	}
+
+	logPassed(t, "Successfully captured params holding dots")
+
+	hashed := HistoryPage(NewMemoryHistory("/"), HashSequencer)
+
+	doc := NewView(item("Doc"))
+	hashed.AddView("/docs/:name", doc)
+	hashed.Follow("/", "#/docs/intro.md")
+
+	if !doc.Active() || doc.Params().Get("name") != "intro.md" {
+		fatalFailed(t, "Expected dotted hash param to be captured but got %q", doc.Params().Get("name"))
+	}
+
+	logPassed(t, "Successfully captured hash params holding dots")
+}
@@ -0,0 +1,194 @@
+package views
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/go-humble/detect"
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Schedulable defines a type whose renders can be batched by a Scheduler.
+type Schedulable interface {
+	Depth() int
+	Flush()
+}
+
+// Nested defines a Schedulable nested within another, which renders it along
+// with itself.
+type Nested interface {
+	Schedulable
+	Parent() Schedulable
+}
+
+// Scheduler defines a type which decides when a scheduled render gets flushed.
+type Scheduler interface {
+	Schedule(Schedulable)
+}
+
+// ImmediateScheduler provides a Scheduler which flushes every render as soon
+// as its scheduled.
+type ImmediateScheduler struct{}
+
+// Immediate provides a shared ImmediateScheduler.
+var Immediate = &ImmediateScheduler{}
+
+// Schedule flushes the Schedulable immediately.
+func (i *ImmediateScheduler) Schedule(s Schedulable) {
+	s.Flush()
+}
+
+// Clock defines a source of ticks used by the BatchScheduler.
+type Clock interface {
+	Next(func())
+}
+
+// AnimationClock provides a Clock which ticks on the browser's
+// requestAnimationFrame.
+type AnimationClock struct{}
+
+// Next requests the function be called on the next animation frame.
+func (a AnimationClock) Next(fx func()) {
+	panicBrowserDetect()
+	js.Global.Call("requestAnimationFrame", fx)
+}
+
+// ManualClock provides a Clock which only ticks when told to, it allows tests
+// to control when batched renders are flushed.
+type ManualClock struct {
+	ro      sync.Mutex
+	pending []func()
+}
+
+// NewManualClock returns a new ManualClock instance.
+func NewManualClock() *ManualClock {
+	return &ManualClock{}
+}
+
+// Next queues the function till the next call to Tick.
+func (m *ManualClock) Next(fx func()) {
+	m.ro.Lock()
+	m.pending = append(m.pending, fx)
+	m.ro.Unlock()
+}
+
+// Pending returns the total functions awaiting the next tick.
+func (m *ManualClock) Pending() int {
+	m.ro.Lock()
+	defer m.ro.Unlock()
+	return len(m.pending)
+}
+
+// Tick calls all the functions queued before the tick.
+func (m *ManualClock) Tick() {
+	m.ro.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.ro.Unlock()
+
+	for _, fx := range pending {
+		fx()
+	}
+}
+
+// BatchScheduler provides a Scheduler which coalesces scheduled renders and
+// flushes each once on the next tick of its clock, with parents flushed
+// before their children.
+type BatchScheduler struct {
+	clock     Clock
+	ro        sync.Mutex
+	dirty     map[Schedulable]bool
+	queue     []Schedulable
+	requested bool
+}
+
+// NewBatchScheduler returns a new BatchScheduler ticking with the clock.
+func NewBatchScheduler(c Clock) *BatchScheduler {
+	return &BatchScheduler{
+		clock: c,
+		dirty: make(map[Schedulable]bool),
+	}
+}
+
+// FrameScheduler returns a new BatchScheduler which flushes once per
+// animation frame.
+func FrameScheduler() *BatchScheduler {
+	return NewBatchScheduler(AnimationClock{})
+}
+
+// frames is the BatchScheduler shared by views rendering within the browser.
+var frames = FrameScheduler()
+
+// DefaultScheduler returns the Scheduler views use unless told otherwise. In
+// the browser renders are batched per animation frame else they are flushed
+// immediately.
+func DefaultScheduler() Scheduler {
+	if detect.IsBrowser() {
+		return frames
+	}
+	return Immediate
+}
+
+// Schedule marks the Schedulable as dirty, requesting a tick if none is
+// pending. Scheduling an already dirty Schedulable does nothing.
+func (b *BatchScheduler) Schedule(s Schedulable) {
+	b.ro.Lock()
+
+	if b.dirty[s] {
+		b.ro.Unlock()
+		return
+	}
+
+	b.dirty[s] = true
+	b.queue = append(b.queue, s)
+
+	request := !b.requested
+	b.requested = true
+	b.ro.Unlock()
+
+	if request {
+		b.clock.Next(b.flush)
+	}
+}
+
+// flush renders all the dirty Schedulables, parents first. Those nested within
+// a dirty Schedulable are skipped as its render renders them too. Anything
+// scheduled while flushing waits for the next tick.
+func (b *BatchScheduler) flush() {
+	b.ro.Lock()
+	queue := b.queue
+	dirty := b.dirty
+	b.queue = nil
+	b.dirty = make(map[Schedulable]bool)
+	b.requested = false
+	b.ro.Unlock()
+
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].Depth() < queue[j].Depth()
+	})
+
+	for _, s := range queue {
+		if !dirtyAncestor(s, dirty) {
+			s.Flush()
+		}
+	}
+}
+
+// dirtyAncestor returns true if any Schedulable the Schedulable is nested
+// within is dirty.
+func dirtyAncestor(s Schedulable, dirty map[Schedulable]bool) bool {
+	for {
+		nested, ok := s.(Nested)
+		if !ok {
+			return false
+		}
+
+		if s = nested.Parent(); s == nil {
+			return false
+		}
+
+		if dirty[s] {
+			return true
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package views
+
+import "testing"
+
+type flushRecord struct {
+	depth  int
+	name   string
+	log    *[]string
+	parent *flushRecord
+}
+
+func (f *flushRecord) Depth() int {
+	return f.depth
+}
+
+func (f *flushRecord) Parent() Schedulable {
+	if f.parent == nil {
+		return nil
+	}
+	return f.parent
+}
+
+func (f *flushRecord) Flush() {
+	*f.log = append(*f.log, f.name)
+}
+
+func TestBatchScheduler(t *testing.T) {
+	var flushed []string
+
+	clock := NewManualClock()
+	scheduler := NewBatchScheduler(clock)
+
+	child := &flushRecord{depth: 2, name: "child", log: &flushed}
+	parent := &flushRecord{depth: 0, name: "parent", log: &flushed}
+
+	for i := 0; i < 10; i++ {
+		scheduler.Schedule(child)
+		scheduler.Schedule(parent)
+	}
+
+	if clock.Pending() != 1 {
+		fatalFailed(t, "Expected a single tick request but got %d", clock.Pending())
+	}
+
+	if len(flushed) != 0 {
+		fatalFailed(t, "Expected no flush before the tick but got %d", len(flushed))
+	}
+
+	clock.Tick()
+
+	if len(flushed) != 2 {
+		fatalFailed(t, "Expected %d flushes but got %d", 2, len(flushed))
+	}
+
+	if flushed[0] != "parent" || flushed[1] != "child" {
+		fatalFailed(t, "Expected parent to flush before child but got %q", flushed)
+	}
+
+	logPassed(t, "Successfully coalesced renders into a single tick")
+
+	scheduler.Schedule(child)
+	clock.Tick()
+
+	if len(flushed) != 3 {
+		fatalFailed(t, "Expected a new tick to flush again but got %d flushes", len(flushed))
+	}
+
+	logPassed(t, "Successfully flushed renders scheduled after a tick")
+}
+
+func TestBatchSchedulerSkipsNested(t *testing.T) {
+	var flushed []string
+
+	clock := NewManualClock()
+	scheduler := NewBatchScheduler(clock)
+
+	parent := &flushRecord{depth: 0, name: "parent", log: &flushed}
+	child := &flushRecord{depth: 1, name: "child", log: &flushed, parent: parent}
+	grandchild := &flushRecord{depth: 2, name: "grandchild", log: &flushed, parent: child}
+
+	scheduler.Schedule(grandchild)
+	scheduler.Schedule(parent)
+	clock.Tick()
+
+	if len(flushed) != 1 || flushed[0] != "parent" {
+		fatalFailed(t, "Expected only the parent to render but got %q", flushed)
+	}
+
+	logPassed(t, "Successfully skipped renders nested within a dirty parent")
+
+	scheduler.Schedule(grandchild)
+	clock.Tick()
+
+	if len(flushed) != 2 || flushed[1] != "grandchild" {
+		fatalFailed(t, "Expected nested render of a clean parent to flush but got %q", flushed)
+	}
+
+	logPassed(t, "Successfully rendered nested Schedulable on its own")
+}
+
+func TestViewScheduler(t *testing.T) {
+	clock := NewManualClock()
+	seq := Sequence(SequenceMeta{}, NewView(item("Book")))
+	view := NewView(seq)
+
+	view.UseScheduler(NewBatchScheduler(clock))
+
+	inner := seq.stack[0].(*View)
+
+	if inner.Depth() != 1 {
+		fatalFailed(t, "Expected nested view to have depth %d but got %d", 1, inner.Depth())
+	}
+
+	if inner.Parent() != Schedulable(view) || view.Parent() != nil {
+		fatalFailed(t, "Expected nested view to be scheduled within its parent")
+	}
+
+	if inner.scheduler != view.scheduler {
+		fatalFailed(t, "Expected nested view to use its parent's scheduler")
+	}
+
+	view.Send(true)
+	view.Send(true)
+
+	if clock.Pending() != 1 {
+		fatalFailed(t, "Expected a single tick request but got %d", clock.Pending())
+	}
+
+	logPassed(t, "Successfully scheduled view renders through the parent's scheduler")
+}
@@ -0,0 +1,102 @@
+package views
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ServeView renders the view for the path of the request into the response.
+// If the view fails to render a 500 status is written, with the view's
+// fallback markup if its Renderable provides one, else a plain error page.
+// Views nested within it which fail also give a 500 status, with the markup
+// of the view holding their fallbacks.
+func ServeView(w http.ResponseWriter, r *http.Request, v *View) {
+	serveView(w, r, v, http.StatusOK)
+}
+
+// serveView renders the view into the response with the status, unless the
+// view fails to render.
+func serveView(w http.ResponseWriter, r *http.Request, v *View, status int) {
+	html := v.RenderHTML(URLPathSequencer(r.URL.Path, ""))
+
+	if v.Failure() != nil {
+		if _, ok := v.rview.(Fallbacker); !ok {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if len(v.Failures()) > 0 {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	io.WriteString(w, string(html))
+}
+
+// Handler returns a http.Handler routing requests through the page's
+// PathSequencer and states as its browser navigations are, so one route table
+// serves both. The states of the request's address are activated before the
+// layout view, which holds the page's views, is served. Addresses matching no
+// state get a 404, with the layout showing the page's not found view if it has
+// one, while the page's guards can redirect a request with a 302 or cancel it
+// with a 403 and its redirect routes answer with a 301. Requests are served one at a time as they share the
+// page's states.
+func (p *Pages) Handler(layout *View) http.Handler {
+	var serve sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serve.Lock()
+		defer serve.Unlock()
+
+		var hash string
+		if r.URL.Fragment != "" {
+			hash = "#" + r.URL.Fragment
+		}
+
+		ps := p.Spec(r.URL.Path, hash)
+		ps.Query = r.URL.Query()
+
+		type decision struct {
+			d  Decision
+			ps PathSpec
+			to []States
+		}
+
+		// requests are decided without a previous route, as the page's
+		// current one belongs to another client's request.
+		decided := make(chan decision, 1)
+		p.decide(PathSpec{}, nil, ps, 0, func(d Decision, ps PathSpec, to []States) {
+			decided <- decision{d, ps, to}
+		})
+
+		var dc decision
+		select {
+		case dc = <-decided:
+		case <-r.Context().Done():
+			return
+		}
+
+		switch {
+		case dc.d.redirect != "" && dc.d.permanent:
+			http.Redirect(w, r, dc.d.redirect, http.StatusMovedPermanently)
+		case dc.d.redirect != "":
+			http.Redirect(w, r, dc.d.redirect, http.StatusFound)
+		case dc.d.cancel:
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		default:
+			if err := p.enter(dc.ps, dc.to); err != nil {
+				if !p.hasNotFound() {
+					http.NotFound(w, r)
+					return
+				}
+
+				serveView(w, r, layout, http.StatusNotFound)
+				return
+			}
+			serveView(w, r, layout, http.StatusOK)
+		}
+	})
+}
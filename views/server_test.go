@@ -0,0 +1,96 @@
+package views
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPagesHandler(t *testing.T) {
+	pages := Page(URLPathSequencer)
+
+	home := NewView(item("Home"))
+	user := NewView(&profile{})
+	admin := NewView(item("Admin"))
+
+	pages.AddView("home", home, HideWith(&UnmountView{}))
+	pages.AddView("/users/:id", user, HideWith(&UnmountView{}))
+	pages.AddView("admin", admin, HideWith(&UnmountView{}))
+
+	admin.BeforeEnter(func(nav Navigation, decide func(Decision)) {
+		decide(Redirect("/home"))
+	})
+
+	handler := pages.Handler(NewView(Sequence(SequenceMeta{}, home, user, admin)))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	rec := serve("/users/12")
+	if body := rec.Body.String(); rec.Code != http.StatusOK || !strings.Contains(body, "user 12") || strings.Contains(body, "Home") {
+		fatalFailed(t, "Expected only the user view to render but got %d: %q", rec.Code, body)
+	}
+
+	rec = serve("/home")
+	if body := rec.Body.String(); !strings.Contains(body, "Home") || strings.Contains(body, "user 12") {
+		fatalFailed(t, "Expected only the home view to render but got %q", body)
+	}
+
+	logPassed(t, "Successfully rendered the views of the request's address")
+
+	if rec = serve("/admin"); rec.Code != http.StatusFound || rec.Header().Get("Location") != "/home" {
+		fatalFailed(t, "Expected guard to redirect but got %d", rec.Code)
+	}
+
+	if rec = serve("/nowhere"); rec.Code != http.StatusNotFound {
+		fatalFailed(t, "Expected unknown address to get a 404 but got %d", rec.Code)
+	}
+
+	logPassed(t, "Successfully redirected and rejected requests")
+}
+
+func TestPagesHandlerIsolatesRequests(t *testing.T) {
+	pages := Page(URLPathSequencer)
+
+	a := NewView(item("A"))
+	b := NewView(item("B"))
+
+	pages.AddView("a", a)
+	pages.AddView("b", b)
+
+	var from []string
+	pages.Use(func(nav Navigation, decide func(Decision)) {
+		from = append(from, nav.From.String())
+		decide(Allow())
+	})
+
+	a.BeforeLeave(func(nav Navigation, decide func(Decision)) {
+		decide(Cancel())
+	})
+
+	handler := pages.Handler(NewView(Sequence(SequenceMeta{}, a, b)))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		return rec
+	}
+
+	if rec := serve("/a"); rec.Code != http.StatusOK {
+		fatalFailed(t, "Expected first request to be served but got %d", rec.Code)
+	}
+
+	if rec := serve("/b"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "B") {
+		fatalFailed(t, "Expected second request to skip the first's leave guards but got %d", rec.Code)
+	}
+
+	if len(from) != 2 || from[0] != "" || from[1] != "" {
+		fatalFailed(t, "Expected requests to navigate from no location but got %q", from)
+	}
+
+	logPassed(t, "Successfully decided requests apart from each other")
+}
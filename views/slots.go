@@ -0,0 +1,36 @@
+package views
+
+import "github.com/influx6/haiku/trees"
+
+// SlotFill provides the markup supplied for a named slot of a view.
+type SlotFill struct {
+	Name   string
+	Markup []trees.Markup
+}
+
+// Fill returns a SlotFill supplying the markup for the named slot, whose events
+// are managed by the caller's EventManagers rather than the view it's
+// projected into.
+func Fill(caller *View, name string, markup ...trees.Markup) SlotFill {
+	for _, m := range markup {
+		m.UseEventManager(caller.events)
+	}
+
+	return SlotFill{Name: name, Markup: markup}
+}
+
+// Fill returns a SlotFill supplying the markup for the named slot, whose events
+// are managed by this view, as Fill(v, name, markup...) does.
+func (v *View) Fill(name string, markup ...trees.Markup) SlotFill {
+	return Fill(v, name, markup...)
+}
+
+// Project sets the fills projected into the slots of the view's render,
+// replacing any previous fills. Slots without a fill render their defaults.
+func (v *View) Project(fills ...SlotFill) {
+	v.fills = make(map[string][]trees.Markup, len(fills))
+
+	for _, fill := range fills {
+		v.fills[fill.Name] = append(v.fills[fill.Name], fill.Markup...)
+	}
+}
@@ -0,0 +1,55 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+	"github.com/influx6/haiku/trees/events"
+)
+
+type card struct{}
+
+func (card) Render(m ...string) trees.Markup {
+	return elems.Div(
+		trees.Slot("header", elems.Text("Untitled")),
+		trees.Slot("body"),
+	)
+}
+
+func TestSlots(t *testing.T) {
+	caller := NewView(item("Book"))
+	cardView := NewView(card{})
+
+	out := string(cardView.RenderHTML())
+	if !strings.Contains(out, "Untitled") {
+		fatalFailed(t, "Expected default slot content but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered default slot content")
+
+	button := elems.Button(elems.Text("Buy"))
+	events.Click(nil, "").Apply(button)
+
+	link := elems.Anchor(elems.Text("More"))
+	events.Click(nil, "").Apply(link)
+
+	cardView.Project(
+		Fill(caller, "header", elems.Text("Books"), link),
+		caller.Fill("body", button),
+	)
+
+	out = string(cardView.RenderHTML())
+	if !strings.Contains(out, "Books") || strings.Contains(out, "Untitled") || !strings.Contains(out, "Buy") {
+		fatalFailed(t, "Expected fills to be projected into slots but got %q", out)
+	}
+
+	logPassed(t, "Successfully projected fills into slots")
+
+	if len(eventIDs(caller.events)) != 2 || len(eventIDs(cardView.events)) != 0 {
+		fatalFailed(t, "Expected the fill's events to be managed by the caller's view")
+	}
+
+	logPassed(t, "Successfully kept fill events with the caller's view")
+}
@@ -9,7 +9,7 @@ import (
 )
 
 // excessStops is a regexp for matching more than one fullstops in the state address which then gets replaced into a single fullstop
-var excessStops = regexp.MustCompile(`.\+`)
+var excessStops = regexp.MustCompile(`\.+`)
 
 // ErrStateNotFound is returned when the state address is inaccurate and a state was not found in the path
 var ErrStateNotFound = errors.New("State Not Found")
@@ -33,7 +33,14 @@ type States interface {
 	UseActivator(StateResponse) States
 	UseDeactivator(StateResponse) States
 	OverrideValidator(StateValidator) States
+	Params() Params
+	Name() string
+	UseName(string) States
+	BeforeEnter(Guard) States
+	BeforeLeave(Guard) States
 	acceptable(string, string) bool
+	guards() ([]Guard, []Guard)
+	useParams(Params)
 }
 
 // State represents a single state of with a specific tag and address
@@ -55,10 +62,19 @@ type State struct {
 	//internal engine that allows sub-states from a root state
 	engine *StateEngine
 
+	// params are the parameters captured by the route patterns along the address which activated the state
+	params Params
+
+	// enter and leave are the guards run before a navigation enters or leaves the state
+	enter, leave []Guard
+
+	// name identifies the state's route when building urls to it
+	name string
+
 	// the parent state this is connected to
 	// parent States
 
-	vo, ro, do sync.Mutex
+	vo, ro, do, po, gu, no sync.Mutex
 }
 
 // NewState builds a new state with a tag and single address point .eg home or files ..etc
@@ -80,6 +96,60 @@ func (s *State) Engine() *StateEngine {
 	return s.engine
 }
 
+// Params returns the parameters captured by the route patterns along the
+// address which last activated the state.
+func (s *State) Params() Params {
+	s.po.Lock()
+	defer s.po.Unlock()
+	return s.params
+}
+
+// useParams sets the parameters captured for the state.
+func (s *State) useParams(p Params) {
+	s.po.Lock()
+	s.params = p
+	s.po.Unlock()
+}
+
+// Name returns the name of the state's route.
+func (s *State) Name() string {
+	s.no.Lock()
+	defer s.no.Unlock()
+	return s.name
+}
+
+// UseName sets the name of the state's route, used by Pages.URL to build urls
+// to it.
+func (s *State) UseName(name string) States {
+	s.no.Lock()
+	s.name = name
+	s.no.Unlock()
+	return s
+}
+
+// BeforeEnter adds a guard run before a navigation enters the state.
+func (s *State) BeforeEnter(g Guard) States {
+	s.gu.Lock()
+	s.enter = append(s.enter, g)
+	s.gu.Unlock()
+	return s
+}
+
+// BeforeLeave adds a guard run before a navigation leaves the state.
+func (s *State) BeforeLeave(g Guard) States {
+	s.gu.Lock()
+	s.leave = append(s.leave, g)
+	s.gu.Unlock()
+	return s
+}
+
+// guards returns the enter and leave guards of the state.
+func (s *State) guards() ([]Guard, []Guard) {
+	s.gu.Lock()
+	defer s.gu.Unlock()
+	return s.enter, s.leave
+}
+
 // UseDeactivator assigns the state a new deactivate respone handler
 func (s *State) UseDeactivator(so StateResponse) States {
 	s.do.Lock()
@@ -160,10 +230,11 @@ func (s *State) acceptable(addr string, point string) bool {
 
 // StateEngine represents the engine that handles the state machine based operations for state-address based states
 type StateEngine struct {
-	rw     sync.RWMutex
-	states map[States]string
-	owner  States
-	curr   States
+	rw       sync.RWMutex
+	states   map[States]string
+	patterns map[States]*pattern
+	owner    States
+	curr     States
 }
 
 // NewStateEngine returns a new engine with a default empty state
@@ -174,13 +245,15 @@ func NewStateEngine() *StateEngine {
 // BuildStateEngine returns a new StateEngine instance set with a particular state as its owner
 func BuildStateEngine(s States) *StateEngine {
 	es := StateEngine{
-		states: make(map[States]string),
-		owner:  s,
+		states:   make(map[States]string),
+		patterns: make(map[States]*pattern),
+		owner:    s,
 	}
 	return &es
 }
 
 // AddState adds a new state into the engine with the tag used to identify the state, if the address is a empty string then the address recieves the tag as its value, remember the address is a single address point .eg home or files and not the length of the extend address eg .root.home.files
+// unless its a route pattern e.g /users/:id/posts/:slug? which can match several points, capturing its parameters
 func (se *StateEngine) AddState(addr string) States {
 	sa := NewState()
 	se.add(addr, sa)
@@ -228,7 +301,7 @@ func (se *StateEngine) Partial(addr string) error {
 		return err
 	}
 
-	return se.trajectory(points, true)
+	return se.trajectory(points, true, se.ownerParams())
 }
 
 // All renders the partial of the last state of the state address
@@ -239,7 +312,15 @@ func (se *StateEngine) All(addr string) error {
 		return err
 	}
 
-	return se.trajectory(points, false)
+	return se.trajectory(points, false, se.ownerParams())
+}
+
+// ownerParams returns the parameters of the engine's owner state if any.
+func (se *StateEngine) ownerParams() Params {
+	if se.owner == nil {
+		return nil
+	}
+	return se.owner.Params()
 }
 
 // DeactivateAll deactivates all states connected to this engine
@@ -301,11 +382,14 @@ func (se *StateEngine) add(addr string, s States) {
 
 	se.rw.Lock()
 	se.states[s] = addr
+	if addr != "." {
+		se.patterns[s] = compilePattern(addr)
+	}
 	se.rw.Unlock()
 }
 
 // trajectory is the real engine which checks the path and passes down the StateStat to the sub-states and determines wether its a full view or partial view
-func (se *StateEngine) trajectory(points []string, partial bool) error {
+func (se *StateEngine) trajectory(points []string, partial bool, params Params) error {
 
 	subs, nosubs := se.diffSubs()
 
@@ -316,6 +400,10 @@ func (se *StateEngine) trajectory(points []string, partial bool) error {
 			ko.Deactivate()
 		}
 
+		for _, ko := range subs {
+			ko.useParams(params)
+		}
+
 		//if the engine has a root state activate it since in doing so,it will activate its own children else manually activate the children
 		if se.owner != nil {
 			se.owner.Activate()
@@ -330,10 +418,8 @@ func (se *StateEngine) trajectory(points []string, partial bool) error {
 		return nil
 	}
 
-	//cache the first point so we dont loose it
-	point := points[0]
-
-	var state = se.get(point)
+	//find the state whose route pattern best matches the points, capturing its parameters
+	state, captured, rest := se.match(points)
 
 	if state == nil {
 		// for _, ko := range nosubs {
@@ -348,14 +434,21 @@ func (se *StateEngine) trajectory(points []string, partial bool) error {
 		// }
 	}
 
+	//deactivate the states off the path, so only the states along it stay active
+	for _, ko := range nosubs {
+		if ko != state {
+			ko.Deactivate()
+		}
+	}
+
 	//set this state as the current active state
 	se.curr = state
 
-	//shift the list one more bit for the points
-	points = points[1:]
+	params = params.merge(captured)
+	state.useParams(params)
 
-	//we pass down the points since that will handle the loadup downwards
-	err := state.Engine().trajectory(points, partial)
+	//we pass down the points left after the match since that will handle the loadup downwards
+	err := state.Engine().trajectory(rest, partial, params)
 
 	if err != nil {
 		return err
@@ -376,6 +469,22 @@ func (se *StateEngine) trajectory(points []string, partial bool) error {
 	return nil
 }
 
+// resolve returns the states the points would activate without activating
+// them, along with the parameters captured along the way.
+func (se *StateEngine) resolve(points []string) ([]States, Params, error) {
+	if len(points) < 1 {
+		return nil, nil, nil
+	}
+
+	state, captured, rest := se.match(points)
+	if state == nil {
+		return nil, nil, ErrStateNotFound
+	}
+
+	subs, params, err := state.Engine().resolve(rest)
+	return append([]States{state}, subs...), captured.merge(params), err
+}
+
 // preparePoints prepares the state address into a list of walk points
 func (se *StateEngine) prepare(addr string) ([]string, error) {
 
@@ -384,8 +493,16 @@ func (se *StateEngine) prepare(addr string) ([]string, error) {
 
 	if addr != "." {
 		addr = excessStops.ReplaceAllString(addr, ".")
+		if len(addr) > 1 {
+			addr = strings.TrimSuffix(addr, ".")
+		}
 		points = strings.Split(addr, ".")
 		polen = len(points)
+
+		// dots escaped by the PathSequencers belong within their point.
+		for i, point := range points {
+			points[i] = pointUnescaper.Replace(point)
+		}
 	} else {
 		polen = 1
 		points = []string{""}
@@ -113,6 +113,78 @@ func TestStateEngineRoot(t *testing.T) {
 	}
 }
 
+func TestStateEnginePatternSpecificity(t *testing.T) {
+	var engine = NewStateEngine()
+
+	listing := engine.AddState("files")
+	files := engine.AddState("files/*path")
+	users := engine.AddState("users")
+	user := engine.AddState("users/:id?")
+
+	if err := engine.All(".files"); err != nil || !listing.Active() || files.Active() {
+		fatalFailed(t, "Expected exact literal to win over a longer wildcard: %v", err)
+	}
+
+	if err := engine.All(".files.a.b"); err != nil || listing.Active() || !files.Active() {
+		fatalFailed(t, "Expected wildcard to match the points left: %v", err)
+	}
+
+	if err := engine.All(".users"); err != nil || !users.Active() || user.Active() {
+		fatalFailed(t, "Expected exact literal to win over a missing optional: %v", err)
+	}
+
+	if err := engine.All(".users.7"); err != nil || users.Active() || !user.Active() {
+		fatalFailed(t, "Expected optional parameter to match a point: %v", err)
+	}
+
+	logPassed(t, "Successfully compared patterns segment by segment")
+}
+
+func TestStateEnginePatterns(t *testing.T) {
+	var engine = NewStateEngine()
+
+	user := engine.AddState("/users/:id")
+	create := engine.AddState("/users/new")
+	post := user.Engine().AddState("posts/:slug?")
+	files := engine.AddState("files/*path")
+
+	if err := engine.All(".users.new"); err != nil || !create.Active() || user.Active() {
+		fatalFailed(t, "Expected literal pattern to win over parameter: %v", err)
+	}
+
+	if err := engine.All(".users.42.posts.hello"); err != nil {
+		fatalFailed(t, "Unable to run pattern state: %s", err)
+	}
+
+	if id, err := post.Params().Int("id"); err != nil || id != 42 {
+		fatalFailed(t, "Expected nested state to receive parent id 42 but got %d: %v", id, err)
+	}
+
+	if slug := post.Params().Get("slug"); slug != "hello" {
+		fatalFailed(t, "Expected slug %q but got %q", "hello", slug)
+	}
+
+	if create.Active() {
+		fatalFailed(t, "Expected state off the address to be deactivated")
+	}
+
+	if err := engine.All(".users.42.posts"); err != nil || post.Params().Has("slug") {
+		fatalFailed(t, "Expected optional slug to be left out: %v", err)
+	}
+
+	logPassed(t, "Successfully captured parameters of route patterns")
+
+	if err := engine.All(".files.docs.readme"); err != nil || files.Params().Get("path") != "docs/readme" {
+		fatalFailed(t, "Expected wildcard to capture %q but got %q: %v", "docs/readme", files.Params().Get("path"), err)
+	}
+
+	if err := engine.All(".users"); err != ErrStateNotFound {
+		fatalFailed(t, "Expected missing required parameter to not match but got %v", err)
+	}
+
+	logPassed(t, "Successfully matched wildcards and rejected incomplete addresses")
+}
+
 func logPassed(t *testing.T, msg string, data ...interface{}) {
 	t.Logf("%s %s", fmt.Sprintf(msg, data...), succeedMark)
 }
@@ -0,0 +1,210 @@
+package views
+
+import (
+	"bytes"
+	"html/template"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+// ParseMarkup parses the html into a single markup. If the html holds a single
+// element it is returned as is, else its contents are wrapped within a div.
+func ParseMarkup(content string) (trees.Markup, error) {
+	markups, err := trees.ParseHTML(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(markups) == 1 && markups[0].Name() != "text" {
+		return markups[0], nil
+	}
+
+	root := elems.Div()
+	for _, m := range markups {
+		root.AddChild(m)
+	}
+
+	return root, nil
+}
+
+// TemplateSet provides a set of html templates loaded from a fs.FS using glob
+// patterns, where every template can use the others as partials by their file
+// name e.g `{{template "header.html" .}}`. When reloading is on, the set is
+// parsed again whenever any of its files changed, which is meant for use
+// during development.
+type TemplateSet struct {
+	fsys     fs.FS
+	patterns []string
+	funcs    template.FuncMap
+	reload   bool
+	ro       sync.Mutex
+	tmpl     *template.Template
+	mods     map[string]time.Time
+}
+
+// NewTemplateSet returns a new TemplateSet loading the files matching the
+// patterns from the fs.FS.
+func NewTemplateSet(fsys fs.FS, funcs template.FuncMap, patterns ...string) (*TemplateSet, error) {
+	ts := TemplateSet{
+		fsys:     fsys,
+		patterns: patterns,
+		funcs:    funcs,
+	}
+
+	if err := ts.parse(); err != nil {
+		return nil, err
+	}
+
+	return &ts, nil
+}
+
+// Reload sets if the set should be parsed again when its files change.
+func (t *TemplateSet) Reload(reload bool) {
+	t.ro.Lock()
+	t.reload = reload
+	t.ro.Unlock()
+}
+
+// Execute runs the named template with the data, parsing its output into
+// markup.
+func (t *TemplateSet) Execute(name string, data interface{}) (trees.Markup, error) {
+	tmpl, err := t.template()
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&out, name, data); err != nil {
+		return nil, err
+	}
+
+	return ParseMarkup(out.String())
+}
+
+// Markup returns a Renderable which renders the named template with the data.
+func (t *TemplateSet) Markup(name string, data interface{}) *TemplateMarkup {
+	return &TemplateMarkup{
+		set:  t,
+		name: name,
+		data: data,
+	}
+}
+
+// template returns the set's templates, parsing them again first if reloading
+// is on and any of its files changed.
+func (t *TemplateSet) template() (*template.Template, error) {
+	t.ro.Lock()
+	defer t.ro.Unlock()
+
+	if t.reload {
+		mods, err := t.modTimes()
+		if err != nil {
+			return nil, err
+		}
+
+		if changed(t.mods, mods) {
+			if err := t.parseLocked(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t.tmpl, nil
+}
+
+// parse parses the files of the set.
+func (t *TemplateSet) parse() error {
+	t.ro.Lock()
+	defer t.ro.Unlock()
+	return t.parseLocked()
+}
+
+// parseLocked parses the files of the set, the set's lock must be held.
+func (t *TemplateSet) parseLocked() error {
+	mods, err := t.modTimes()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("").Funcs(t.funcs).ParseFS(t.fsys, t.patterns...)
+	if err != nil {
+		return err
+	}
+
+	t.tmpl = tmpl
+	t.mods = mods
+	return nil
+}
+
+// modTimes returns the modification time of every file matching the set's
+// patterns.
+func (t *TemplateSet) modTimes() (map[string]time.Time, error) {
+	mods := make(map[string]time.Time)
+
+	for _, pattern := range t.patterns {
+		files, err := fs.Glob(t.fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			info, err := fs.Stat(t.fsys, file)
+			if err != nil {
+				return nil, err
+			}
+			mods[file] = info.ModTime()
+		}
+	}
+
+	return mods, nil
+}
+
+// changed returns true if the files or their modification times differ.
+func changed(prev, next map[string]time.Time) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+
+	for file, mod := range next {
+		if pm, ok := prev[file]; !ok || !pm.Equal(mod) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TemplateMarkup provides a Renderable which renders a template of a
+// TemplateSet as markup, allowing Go templates to be used as views.
+type TemplateMarkup struct {
+	set  *TemplateSet
+	ro   sync.RWMutex
+	name string
+	data interface{}
+}
+
+// UseData sets the data the template renders with.
+func (t *TemplateMarkup) UseData(data interface{}) {
+	t.ro.Lock()
+	t.data = data
+	t.ro.Unlock()
+}
+
+// Render executes the template. It panics if the template fails, allowing the
+// view's error boundary to handle the failure.
+func (t *TemplateMarkup) Render(m ...string) trees.Markup {
+	t.ro.RLock()
+	data := t.data
+	t.ro.RUnlock()
+
+	dom, err := t.set.Execute(t.name, data)
+	if err != nil {
+		panic(err)
+	}
+
+	return dom
+}
@@ -0,0 +1,61 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestTemplateSet(t *testing.T) {
+	files := fstest.MapFS{
+		"pages/item.html":  {Data: []byte(`<div class="item">{{template "title.html" .}}</div>`)},
+		"pages/title.html": {Data: []byte(`<h1>{{.}}</h1>`)},
+	}
+
+	set, err := NewTemplateSet(files, nil, "pages/*.html")
+	if err != nil {
+		fatalFailed(t, "Expected templates to be parsed: %s", err)
+	}
+
+	page := set.Markup("item.html", "<Book>")
+	view := NewView(page)
+
+	out := string(view.RenderHTML())
+	if !strings.Contains(out, "<h1") || !strings.Contains(out, "&lt;Book&gt;") {
+		fatalFailed(t, "Expected rendered template with its partial but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered template with partial as markup")
+
+	set.Reload(true)
+	files["pages/title.html"] = &fstest.MapFile{Data: []byte(`<h2>{{.}}</h2>`), ModTime: time.Now()}
+
+	page.UseData("Funch")
+	out = string(view.RenderHTML())
+	if !strings.Contains(out, "<h2") || !strings.Contains(out, "Funch") {
+		fatalFailed(t, "Expected changed partial to be reloaded but got %q", out)
+	}
+
+	logPassed(t, "Successfully reloaded changed templates")
+}
+
+func TestTemplateFailure(t *testing.T) {
+	files := fstest.MapFS{
+		"item.html": {Data: []byte(`<div>{{.Missing.Field}}</div>`)},
+	}
+
+	set, err := NewTemplateSet(files, nil, "*.html")
+	if err != nil {
+		fatalFailed(t, "Expected templates to be parsed: %s", err)
+	}
+
+	view := NewView(set.Markup("item.html", struct{ Missing *struct{ Field string } }{}))
+	view.Render()
+
+	if view.Failure() == nil {
+		fatalFailed(t, "Expected failed template to be reported as a render failure")
+	}
+
+	logPassed(t, "Successfully reported failed template execution")
+}
@@ -0,0 +1,278 @@
+package views
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-humble/detect"
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/influx6/haiku/trees"
+)
+
+// DefaultTransitionTimeout is the time a Transition waits for the
+// transitionend or animationend of its view before finishing.
+const DefaultTransitionTimeout = 500 * time.Millisecond
+
+// Transitioner defines a ViewStates which changes the view's markup in stages
+// over time, beginning each time the view switches to it.
+type Transitioner interface {
+	ViewStates
+	Begin(*View)
+}
+
+// stage defines the stages of a transitionState.
+type stage int
+
+const (
+	stageStart stage = iota
+	stageActive
+	stageDone
+)
+
+// Transition provides ViewStates transitioning a view's markup in and out
+// using css classes named after the transition, in the style of
+// `fade-enter`, `fade-enter-active` and `fade-leave-active`. Entering applies
+// the `-enter` class, then the `-enter-active` class on the next frame, while
+// leaving applies the `-leave-active` class. Each waits for the transitionend
+// or animationend of the view's markup, or its timeout, before the classes
+// are dropped, after which a left view is hidden.
+//
+// Outside the browser there's nothing to animate, so the states render as if
+// their transition had finished, leaving server rendered markup without any
+// of the classes.
+type Transition struct {
+	name    string
+	timeout time.Duration
+	clock   Clock
+}
+
+// NewTransition returns a new Transition using the css classes of the name.
+func NewTransition(name string) *Transition {
+	return &Transition{
+		name:    name,
+		timeout: DefaultTransitionTimeout,
+	}
+}
+
+// UseTimeout sets the time waited for the view's transition to end.
+func (t *Transition) UseTimeout(d time.Duration) *Transition {
+	t.timeout = d
+	return t
+}
+
+// UseClock sets the Clock used to move an entering view into its active
+// stage, by default the browser's animation frames are used.
+func (t *Transition) UseClock(c Clock) *Transition {
+	t.clock = c
+	return t
+}
+
+// Enter returns the ViewStates transitioning a view in, each view needs its
+// own.
+func (t *Transition) Enter() ViewStates {
+	return &transitionState{Transition: t, stage: stageDone}
+}
+
+// Leave returns the ViewStates transitioning a view out, once the view has
+// left the done state is applied to its markup, by default a HideView. Each
+// view needs its own.
+func (t *Transition) Leave(done ViewStates) ViewStates {
+	if done == nil {
+		done = &HideView{}
+	}
+
+	return &transitionState{Transition: t, leave: true, done: done, stage: stageDone}
+}
+
+// ticker returns the Clock used by the transition, nil when there's no
+// browser to transition in.
+func (t *Transition) ticker() Clock {
+	if t.clock != nil {
+		return t.clock
+	}
+
+	if detect.IsBrowser() {
+		return AnimationClock{}
+	}
+
+	return nil
+}
+
+// UseTransition sets the view's ShowState and HideState to the enter and leave
+// states of the transition, the view's HideState is applied once it has left.
+func (v *View) UseTransition(t *Transition) {
+	v.UseShowState(t.Enter())
+	v.UseHideState(t.Leave(v.HideState))
+}
+
+// transitionState provides the enter or leave ViewStates of a Transition.
+type transitionState struct {
+	*Transition
+	ro    sync.Mutex
+	leave bool
+	done  ViewStates
+	stage stage
+	gen   int
+}
+
+// Render applies the classes of the current stage to the markup.
+func (ts *transitionState) Render(m trees.Markup) {
+	ts.ro.Lock()
+	current := ts.stage
+	ts.ro.Unlock()
+
+	switch {
+	case ts.leave && current == stageDone:
+		ts.done.Render(m)
+	case ts.leave:
+		addClass(m, ts.name+"-leave-active")
+	case current == stageStart:
+		addClass(m, ts.name+"-enter")
+	case current == stageActive:
+		addClass(m, ts.name+"-enter-active")
+	}
+}
+
+// Begin starts the transition of the view, any transition still running from
+// an earlier Begin is abandoned.
+func (ts *transitionState) Begin(v *View) {
+	clock := ts.ticker()
+
+	ts.ro.Lock()
+	ts.gen++
+	gen := ts.gen
+
+	switch {
+	case clock == nil:
+		ts.stage = stageDone
+	case ts.leave:
+		ts.stage = stageActive
+	default:
+		ts.stage = stageStart
+	}
+	ts.ro.Unlock()
+
+	if clock == nil {
+		ts.finish(v)
+		return
+	}
+
+	v.Send(true)
+
+	if ts.leave {
+		ts.await(v, gen)
+		return
+	}
+
+	// the start class has to reach the dom before the active class replaces
+	// it, so the active stage waits for the frame after the next.
+	clock.Next(func() {
+		clock.Next(func() {
+			if ts.advance(gen, stageActive) {
+				v.Send(true)
+				ts.await(v, gen)
+			}
+		})
+	})
+}
+
+// await finishes the transition once the view's markup ends its transition
+// or animation, or the timeout passes, whichever comes first. The listeners
+// and timer are released as soon as either fires.
+func (ts *transitionState) await(v *View, gen int) {
+	var ro sync.Mutex
+	var fired bool
+	var node *js.Object
+	var timer *time.Timer
+	var finish func()
+
+	finish = func() {
+		ro.Lock()
+		if fired {
+			ro.Unlock()
+			return
+		}
+		fired = true
+		ro.Unlock()
+
+		timer.Stop()
+
+		if node != nil {
+			node.Call("removeEventListener", "transitionend", finish)
+			node.Call("removeEventListener", "animationend", finish)
+		}
+
+		if ts.advance(gen, stageDone) {
+			ts.finish(v)
+		}
+	}
+
+	// the listeners and timer are only released once all are set.
+	ro.Lock()
+	defer ro.Unlock()
+
+	if detect.IsBrowser() {
+		if node = v.node(); node != nil {
+			node.Call("addEventListener", "transitionend", finish)
+			node.Call("addEventListener", "animationend", finish)
+		}
+	}
+
+	timer = time.AfterFunc(ts.timeout, finish)
+}
+
+// finish renders the view once its transition is done, beginning the done
+// state of a left view if its a Transitioner.
+func (ts *transitionState) finish(v *View) {
+	if tr, ok := ts.done.(Transitioner); ok && ts.leave {
+		tr.Begin(v)
+		return
+	}
+
+	v.Send(true)
+}
+
+// advance moves the transition to the stage, returning false if the
+// transition was abandoned or is already past it.
+func (ts *transitionState) advance(gen int, s stage) bool {
+	ts.ro.Lock()
+	defer ts.ro.Unlock()
+
+	if ts.gen != gen || ts.stage >= s {
+		return false
+	}
+
+	ts.stage = s
+	return true
+}
+
+// node returns the dom node of the view's markup, else nil if the view is
+// not mounted.
+func (v *View) node() *js.Object {
+	dom := v.dom
+	if dom == nil {
+		if root := v.mountedRoot(); root != nil {
+			dom = root.dom
+		}
+	}
+
+	if dom == nil {
+		return nil
+	}
+
+	return findUID(dom, v.uid)
+}
+
+// addClass adds the class to the markup's class attribute, adding the
+// attribute if the markup has none.
+func addClass(m trees.Markup, class string) {
+	if ca, err := trees.GetAttr(m, "class"); err == nil {
+		ca.Value = strings.TrimSpace(ca.Value + " " + class)
+		return
+	}
+
+	if em, ok := m.(*trees.Element); ok {
+		trees.NewAttr("class", class).Apply(em)
+	}
+}
@@ -0,0 +1,66 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransition(t *testing.T) {
+	clock := NewManualClock()
+	view := NewView(item("Book"))
+	view.UseTransition(NewTransition("fade").UseTimeout(10 * time.Millisecond).UseClock(clock))
+
+	if out := string(view.RenderHTML()); strings.Contains(out, "fade-") {
+		fatalFailed(t, "Expected first render not to transition but got %q", out)
+	}
+
+	view.Hide()
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, "fade-leave-active") {
+		fatalFailed(t, "Expected leaving view to have leave class but got %q", out)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if out := string(view.RenderHTML()); strings.Contains(out, "fade-") || !strings.Contains(out, "display:none") {
+		fatalFailed(t, "Expected left view to be hidden without classes but got %q", out)
+	}
+
+	logPassed(t, "Successfully transitioned view out")
+
+	view.Show()
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, `class="fade-enter"`) {
+		fatalFailed(t, "Expected entering view to have enter class but got %q", out)
+	}
+
+	clock.Tick()
+	clock.Tick()
+
+	if out := string(view.RenderHTML()); !strings.Contains(out, `class="fade-enter-active"`) {
+		fatalFailed(t, "Expected entering view to have active class but got %q", out)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+
+	if out := string(view.RenderHTML()); strings.Contains(out, "fade-") || strings.Contains(out, "display:none") {
+		fatalFailed(t, "Expected entered view to be shown without classes but got %q", out)
+	}
+
+	logPassed(t, "Successfully transitioned view in")
+}
+
+func TestTransitionServer(t *testing.T) {
+	view := NewView(item("Book"))
+	view.UseTransition(NewTransition("fade"))
+
+	view.Render()
+	view.Hide()
+
+	if out := string(view.RenderHTML()); strings.Contains(out, "fade-") || !strings.Contains(out, "display:none") {
+		fatalFailed(t, "Expected hidden view to skip its transition on the server but got %q", out)
+	}
+
+	logPassed(t, "Successfully rendered final state without a browser")
+}
@@ -0,0 +1,55 @@
+package views
+
+import "strings"
+
+// Named returns a ViewOption naming the view's route, so urls to it can be
+// built with Pages.URL instead of by hand.
+func Named(name string) ViewOption {
+	return func(v Views) {
+		v.UseName(name)
+	}
+}
+
+// URL returns the url of the route named by name, with its parameters replaced
+// by the values of the params. The url is a hash e.g `#/users/7/posts` if the
+// page navigates by hash, else a path e.g `/users/7/posts`, either can be
+// given to HistoryProvider.Go.
+func (p *Pages) URL(name string, params map[string]string) (string, error) {
+	addrs, ok := p.named(name)
+	if !ok {
+		return "", ErrStateNotFound
+	}
+
+	path, err := compilePattern(strings.Join(addrs, "/")).expand(params)
+	if err != nil {
+		return "", err
+	}
+
+	if p.usingHash {
+		return "#" + path, nil
+	}
+
+	return path, nil
+}
+
+// named returns the addresses of the states leading to the state with the
+// name, searching through the engine and those of its states.
+func (se *StateEngine) named(name string) ([]string, bool) {
+	var addrs []string
+	var found bool
+
+	se.eachState(func(so States, addr string, stop func()) {
+		if so.Name() == name {
+			addrs, found = []string{addr}, true
+			stop()
+			return
+		}
+
+		if sub, ok := so.Engine().named(name); ok {
+			addrs, found = append([]string{addr}, sub...), true
+			stop()
+		}
+	})
+
+	return addrs, found
+}
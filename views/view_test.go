@@ -74,3 +74,56 @@ func TestSequenceView(t *testing.T) {
 
 	t.Logf("\t%s\tShould contain %q inside rendered output", success, []string{"+ Book", "+ Funch", "+ Fudder"})
 }
+
+type lifecycleItem struct {
+	renders int
+	shown   int
+	hidden  int
+	update  bool
+}
+
+func (l *lifecycleItem) Render(m ...string) trees.Markup {
+	l.renders++
+	return elems.Span(elems.Text(fmt.Sprintf("render %d", l.renders)))
+}
+
+func (l *lifecycleItem) ShouldUpdate(addr string) bool {
+	return l.update
+}
+
+func (l *lifecycleItem) OnShow() {
+	l.shown++
+}
+
+func (l *lifecycleItem) OnHide() {
+	l.hidden++
+}
+
+func TestViewLifecycle(t *testing.T) {
+	li := &lifecycleItem{update: true}
+	view := NewView(li)
+
+	view.Render()
+	second := view.Render()
+
+	if li.shown != 1 {
+		fatalFailed(t, "Expected OnShow to be called once but got %d", li.shown)
+	}
+
+	view.Hide()
+	view.Hide()
+
+	if li.hidden != 1 {
+		fatalFailed(t, "Expected OnHide to be called once but got %d", li.hidden)
+	}
+
+	logPassed(t, "Successfully notified Renderable of state changes")
+
+	li.update = false
+
+	if view.Render() != second || li.renders != 2 {
+		fatalFailed(t, "Expected ShouldUpdate to keep the previous render but got %d renders", li.renders)
+	}
+
+	logPassed(t, "Successfully skipped render with ShouldUpdate")
+}
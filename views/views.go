@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/gopherjs/gopherjs/js"
 	"github.com/influx6/haiku/base"
@@ -47,8 +48,12 @@ type Views interface {
 
 	Events() base.EventManagers
 	Mount(*js.Object)
+	Unmount()
+	UseScheduler(Scheduler)
 	BindView(Views)
 	UseHistory(*HistoryProvider)
+	UseHideState(ViewStates)
+	UseTransition(*Transition)
 	History() (*HistoryProvider, error)
 }
 
@@ -60,14 +65,10 @@ type ViewStates interface {
 // HideView provides a ViewStates for Views inactive state
 type HideView struct{}
 
-// Render marks the given markup as display:none
+// Render marks the given markup as display:none, adding the style if the
+// markup has none.
 func (v *HideView) Render(m trees.Markup) {
-	//if we are allowed to query for styles then check and change display
-	if ds, err := trees.GetStyle(m, "display"); err == nil {
-		if !strings.Contains(ds.Value, "none") {
-			ds.Value = "none"
-		}
-	}
+	setStyle(m, "display", "none")
 }
 
 // ShowView provides a ViewStates for Views active state
@@ -83,6 +84,19 @@ func (v *ShowView) Render(m trees.Markup) {
 	}
 }
 
+// setStyle sets the value of the markup's style, adding the style if the markup
+// has none.
+func setStyle(m trees.Markup, name, value string) {
+	if ds, err := trees.GetStyle(m, name); err == nil {
+		ds.Value = value
+		return
+	}
+
+	if em, ok := m.(*trees.Element); ok {
+		trees.NewStyle(name, value).Apply(em)
+	}
+}
+
 // View represent a basic Haiku view
 type View struct {
 	States
@@ -91,11 +105,19 @@ type View struct {
 	ShowState   ViewStates
 	activeState ViewStates
 	history     *HistoryProvider
+	scheduler   Scheduler
+	parent      *View
 	encoder     trees.MarkupWriter
 	events      base.EventManagers
 	dom         *js.Object
 	rview       Renderable
+	instrument  Instrument
 	liveMarkup  trees.Markup //liveMarkup represent the current rendered markup
+	fallback    trees.Markup //fallback represent the rendered markup of a failed render
+	fills       map[string][]trees.Markup
+	ctx         *Context
+	failure     error
+	nested      []error
 	backdoor    trees.MutableBackdoor
 	loaded      int32
 	uid         string
@@ -133,25 +155,37 @@ func MakeView(uid string, writer trees.MarkupWriter, vw Renderable) (vm *View) {
 		States:    NewState(),
 		HideState: &HideView{},
 		ShowState: &ShowView{},
+		scheduler: DefaultScheduler(),
 		events:    base.NewEventManager(),
 		encoder:   writer,
 		rview:     vw,
 		uid:       uid,
 	}
 
+	vm.ctx = newContext(vm)
+
 	// If its a ReactiveRenderable type then bind the view
 	if rxv, ok := vw.(ReactiveRenderable); ok {
 		rxv.Bind(vm, true)
 	}
 
-	//set up the reaction chain, if we have node attach then render to it
-	vm.React(func(r pub.Publisher, _ error, _ interface{}) {
-		//if we are not domless then patch
-		if vm.dom != nil {
-			replaceOnly := atomic.LoadInt32(&vm.loaded) == 0
-			html := vm.RenderHTML()
-			Patch(CreateFragment(string(html)), vm.dom, replaceOnly)
+	// If its nestable then let it know its parent view
+	if nv, ok := vw.(nestable); ok {
+		nv.adopt(vm)
+	}
+
+	// If its Contextual then pass it the view's context
+	if cx, ok := vw.(Contextual); ok {
+		cx.UseContext(vm.ctx)
+	}
+
+	//set up the reaction chain, schedule a render which patches the dom if any.
+	//Errors are left to bubble up to the view's subscribers.
+	vm.React(func(r pub.Publisher, err error, _ interface{}) {
+		if err != nil {
+			return
 		}
+		vm.scheduler.Schedule(vm)
 	}, true)
 
 	vm.States.UseActivator(func() {
@@ -165,6 +199,132 @@ func MakeView(uid string, writer trees.MarkupWriter, vw Renderable) (vm *View) {
 	return
 }
 
+// nestable defines a Renderable which can be nested within a parent view.
+type nestable interface {
+	adopt(*View)
+}
+
+// schedulable defines a Renderable which renders through a Scheduler.
+type schedulable interface {
+	UseScheduler(Scheduler)
+}
+
+// adopt sets the parent of the view, the view takes up its parent's scheduler
+// and its context continues to its parent's.
+func (v *View) adopt(p *View) {
+	v.parent = p
+	v.ctx.setParent(p.ctx)
+	v.UseScheduler(p.scheduler)
+}
+
+// UseScheduler sets the Scheduler used to batch the view's renders, passing it
+// down to its Renderable if it accepts one.
+func (v *View) UseScheduler(s Scheduler) {
+	if s == nil {
+		s = DefaultScheduler()
+	}
+
+	v.scheduler = s
+
+	if sv, ok := v.rview.(schedulable); ok {
+		sv.UseScheduler(s)
+	}
+}
+
+// Depth returns the total ancestors of the view.
+func (v *View) Depth() int {
+	if v.parent == nil {
+		return 0
+	}
+	return v.parent.Depth() + 1
+}
+
+// Parent returns the view the view is nested within, else nil.
+func (v *View) Parent() Schedulable {
+	if v.parent == nil {
+		return nil
+	}
+	return v.parent
+}
+
+// Flush renders the view and patches its dom if it has one, else if its nested
+// within a mounted view it patches only its own markup within that view's dom.
+// Flush is called by the view's Scheduler, use Send to request a render.
+func (v *View) Flush() {
+	if v.dom != nil {
+		v.patch()
+		return
+	}
+
+	if root := v.mountedRoot(); root != nil && v.liveMarkup != nil {
+		v.patchNested(root)
+	}
+}
+
+// mountedRoot returns the closest ancestor of the view which has rendered into
+// its dom, else nil.
+func (v *View) mountedRoot() *View {
+	for p := v.parent; p != nil; p = p.parent {
+		if p.dom != nil && atomic.LoadInt32(&p.loaded) == 1 {
+			return p
+		}
+	}
+	return nil
+}
+
+// patchNested renders the nested view and patches its markup within the dom of
+// its mounted ancestor, swapping the new markup into its parent's markup so the
+// ancestors keep mirroring the dom.
+func (v *View) patchNested(root *View) {
+	prev := v.liveMarkup
+	next := v.Render()
+
+	// the dom holds the fallback of a failed render rather than the live
+	// markup, so the view's markup is replaced wholesale.
+	if v.failure != nil || v.fallback != nil {
+		shown := prev
+		if v.fallback != nil {
+			shown = v.fallback
+		}
+
+		if shown == next {
+			return
+		}
+
+		PatchDOM(root.dom, []trees.Patch{{Op: trees.PatchReplace, UID: v.uid, Markup: next}})
+
+		if v.parent.liveMarkup != nil {
+			trees.Replace(v.parent.liveMarkup, shown, next)
+		}
+
+		v.fallback = v.fallenMarkup(next)
+		return
+	}
+
+	if prev == next {
+		return
+	}
+
+	v.applyPatches(root.dom, trees.Diff(prev, next))
+
+	if v.parent.liveMarkup != nil {
+		trees.Replace(v.parent.liveMarkup, prev, next)
+	}
+
+	if uv, ok := v.rview.(Updater); ok {
+		uv.OnUpdate(prev)
+	}
+}
+
+// fallenMarkup returns the markup if its the fallback of a failed render, else
+// nil.
+func (v *View) fallenMarkup(m trees.Markup) trees.Markup {
+	if v.failure != nil && m != v.liveMarkup {
+		return m
+	}
+	return nil
+}
+
 // UseHistory sets the views HistoryProvider to effect navigation change.
 func (v *View) UseHistory(hs *HistoryProvider) {
 	v.history = hs
@@ -188,24 +348,86 @@ func (v *View) Mount(dom *js.Object) {
 	v.dom = dom
 	v.events.OffloadDOM()
 	v.events.LoadDOM(dom)
+	atomic.StoreInt32(&v.loaded, 0)
 	v.Send(true)
-	atomic.StoreInt32(&v.loaded, 1)
 }
 
-// Show activates the view to generate a visible markup
+// Unmount removes the view's markup from its dom and releases its events,
+// notifying its Renderable if its an Unmounter.
+func (v *View) Unmount() {
+	if v.dom == nil {
+		return
+	}
+
+	if node := findUID(v.dom, v.uid); node != nil {
+		node.Get("parentNode").Call("removeChild", node)
+	}
+
+	v.events.OffloadDOM()
+	v.dom = nil
+	v.liveMarkup = nil
+	v.fallback = nil
+	atomic.StoreInt32(&v.loaded, 0)
+
+	v.OnUnmount()
+}
+
+// Show activates the view to generate a visible markup, notifying its
+// Renderable if its a Shower.
 func (v *View) Show() {
 	if v.ShowState == nil {
 		v.ShowState = &ShowView{}
 	}
+
+	if v.activeState == v.ShowState {
+		return
+	}
+
 	v.activeState = v.ShowState
+	v.transition()
+
+	if sv, ok := v.rview.(Shower); ok {
+		sv.OnShow()
+	}
 }
 
-// Hide deactivates the view
+// Hide deactivates the view, notifying its Renderable if its a Hider.
 func (v *View) Hide() {
 	if v.HideState == nil {
 		v.HideState = &HideView{}
 	}
+
+	if v.activeState == v.HideState {
+		return
+	}
+
 	v.activeState = v.HideState
+	v.transition()
+
+	if hv, ok := v.rview.(Hider); ok {
+		hv.OnHide()
+	}
+}
+
+// transition begins the view's active state if its a Transitioner, else renders
+// the view again so the state is applied to its markup. A view yet to render
+// has nothing to transition from.
+func (v *View) transition() {
+	if v.liveMarkup == nil {
+		return
+	}
+
+	if tr, ok := v.activeState.(Transitioner); ok {
+		tr.Begin(v)
+		return
+	}
+
+	v.Send(true)
+}
+
+// hidden returns true if the view's active state is its HideState.
+func (v *View) hidden() bool {
+	return v.activeState != nil && v.activeState == v.HideState
 }
 
 // Events returns the views events manager
@@ -219,41 +441,133 @@ func (v *View) Render(m ...string) trees.Markup {
 		m = []string{"."}
 	}
 
-	v.Engine().All(m[0])
+	// a hidden view stays hidden till its shown, rather than activating itself
+	// as it renders.
+	if !v.hidden() {
+		v.Engine().All(m[0])
+	}
 
 	if v.rview == nil {
 		return elems.Div()
 	}
 
-	dom := v.rview.Render(m...)
+	if rv, ok := v.rview.(Routed); ok {
+		rv.UseParams(v.Params())
+	}
+
+	if uc, ok := v.rview.(UpdateChecker); ok && v.liveMarkup != nil {
+		if !uc.ShouldUpdate(m[0]) {
+			return v.liveMarkup
+		}
+	}
+
+	in := v.instruments()
+	start := time.Now()
+
+	// the failures of nested views are collected again as they render.
+	v.nested = nil
+
+	dom, err := v.renderSafely(m...)
+	if err != nil {
+		return v.fail(err)
+	}
+
+	rendered := time.Since(start)
+
+	v.failure = nil
 
 	if dom == nil {
 		return elems.Div()
 	}
 
+	trees.FillSlots(dom, v.fills)
+
 	// // swap the uid for the new dom
 	// // to ensure we keep the sync between backend and frontend in sync.
 	v.backdoor.M = dom
 	v.backdoor.SwapUID(v.uid)
 	v.backdoor.M = nil
 
-	if v.liveMarkup != nil {
-		dom.Reconcile(v.liveMarkup)
+	if v.activeState != nil {
+		v.activeState.Render(dom)
 	}
 
-	dom.UseEventManager(v.events)
-	v.events.LoadUpEvents()
+	v.reconcile(dom, in, rendered)
 	v.liveMarkup = dom
 
 	return dom
 }
 
+// patch renders the view and applies the changes from its last render directly
+// to its dom. The first render after a mount replaces the dom wholesale, as the
+// dom may have been rendered elsewhere (e.g on the server).
+func (v *View) patch() {
+	prev := v.liveMarkup
+	next := v.Render()
+
+	if atomic.SwapInt32(&v.loaded, 1) == 0 || prev == nil {
+		ReplaceDOM(v.dom, next)
+		v.fallback = v.fallenMarkup(next)
+		v.OnMount(v.dom)
+		return
+	}
+
+	// the dom holds the fallback of a failed render rather than the live
+	// markup, so it can't be patched and is replaced wholesale.
+	if v.failure != nil || v.fallback != nil {
+		shown := prev
+		if v.fallback != nil {
+			shown = v.fallback
+		}
+
+		if shown != next {
+			ReplaceDOM(v.dom, next)
+		}
+
+		v.fallback = v.fallenMarkup(next)
+		return
+	}
+
+	// the previous render was kept, so there is nothing to patch.
+	if prev == next {
+		return
+	}
+
+	v.applyPatches(v.dom, trees.Diff(prev, next))
+
+	if uv, ok := v.rview.(Updater); ok {
+		uv.OnUpdate(prev)
+	}
+}
+
 // RenderHTML renders out the views markup as a string wrapped with template.HTML
 func (v *View) RenderHTML(m ...string) template.HTML {
-	ma, _ := v.encoder.Write(v.Render(m...))
+	dom := v.Render(m...)
+
+	in := v.instruments()
+	if in == nil {
+		ma, _ := v.encoder.Write(dom)
+		return template.HTML(ma)
+	}
+
+	ma, _ := trees.InstrumentWriter(v.encoder, printReporter{uid: v.uid, in: in}).Write(dom)
 	return template.HTML(ma)
 }
 
+// applyPatches applies the patches to the dom, reporting them to the view's
+// Instrument if any.
+func (v *View) applyPatches(dom *js.Object, patches []trees.Patch) {
+	in := v.instruments()
+	if in == nil {
+		PatchDOM(dom, patches)
+		return
+	}
+
+	start := time.Now()
+	PatchDOM(dom, patches)
+	in.Patched(PatchMetric{UID: v.uid, Ops: len(patches), Duration: time.Since(start)})
+}
+
 // SequenceMeta  provides a configuration object for SequenceRenderer.
 type SequenceMeta struct {
 	Tag   string   // Name of the root tag.
@@ -269,7 +583,9 @@ type SequenceMeta struct {
 type SequenceRenderer struct {
 	pub.Publisher
 	*SequenceMeta
-	stack []Renderable
+	stack     []Renderable
+	owner     *View
+	scheduler Scheduler
 }
 
 // Sequence returns a new sequence renderer instance.
@@ -294,10 +610,49 @@ func (s *SequenceRenderer) Add(r ...Renderable) {
 		if rx, ok := rm.(ReactiveRenderable); ok {
 			rx.Bind(s, true)
 		}
+
+		if s.owner != nil {
+			if nv, ok := rm.(nestable); ok {
+				nv.adopt(s.owner)
+			}
+
+			if cx, ok := rm.(Contextual); ok {
+				cx.UseContext(s.owner.ctx)
+			}
+		}
+
+		if s.scheduler != nil {
+			if sv, ok := rm.(schedulable); ok {
+				sv.UseScheduler(s.scheduler)
+			}
+		}
+
 		s.stack = append(s.stack, rm)
 	}
 }
 
+// adopt sets the view owning the sequence as the parent of its Renderables.
+func (s *SequenceRenderer) adopt(p *View) {
+	s.owner = p
+
+	for _, rm := range s.stack {
+		if nv, ok := rm.(nestable); ok {
+			nv.adopt(p)
+		}
+	}
+}
+
+// UseScheduler sets the Scheduler used by the Renderables in the sequence.
+func (s *SequenceRenderer) UseScheduler(sc Scheduler) {
+	s.scheduler = sc
+
+	for _, rm := range s.stack {
+		if sv, ok := rm.(schedulable); ok {
+			sv.UseScheduler(sc)
+		}
+	}
+}
+
 // Render renders the giving giving lists of views.
 func (s *SequenceRenderer) Render(m ...string) trees.Markup {
 	root := trees.NewElement(s.Tag, false)
@@ -0,0 +1,216 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/influx6/faux/domevents"
+	"github.com/influx6/haiku/pub"
+	"github.com/influx6/haiku/shared"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/attrs"
+	"github.com/influx6/haiku/trees/elems"
+	"github.com/influx6/haiku/trees/events"
+	"github.com/influx6/haiku/trees/styles"
+)
+
+// DefaultOverscan is the number of rows a VirtualList renders beyond each
+// edge of its visible window.
+const DefaultOverscan = 5
+
+// VirtualList provides a ReactiveRenderable for lists too large to render
+// whole, rendering only the rows within its scrolled window along with an
+// overscan of rows on either side, while spacers above and below keep the
+// scroll height of the full list. Rows carry a key so they keep their dom
+// nodes as the list scrolls. VirtualList embeds pub.Publisher and sends on
+// every scroll which moves its window.
+type VirtualList struct {
+	pub.Publisher
+	ro        sync.RWMutex
+	id        string
+	count     int
+	rowHeight int
+	height    int
+	overscan  int
+	scrollTop int
+	heights   map[int]int
+	row       func(index int) trees.Markup
+	key       func(index int) string
+}
+
+// NewVirtualList returns a new VirtualList of count rows rendered by the row
+// function, every row estimated at rowHeight pixels within a viewport of
+// height pixels.
+func NewVirtualList(count, rowHeight, height int, row func(index int) trees.Markup) *VirtualList {
+	if rowHeight <= 0 {
+		rowHeight = 1
+	}
+
+	return &VirtualList{
+		Publisher: pub.Identity(),
+		id:        shared.RandString(8),
+		count:     count,
+		rowHeight: rowHeight,
+		height:    height,
+		overscan:  DefaultOverscan,
+		heights:   make(map[int]int),
+		row:       row,
+		key:       strconv.Itoa,
+	}
+}
+
+// UseOverscan sets the number of rows rendered beyond each edge of the window.
+func (vl *VirtualList) UseOverscan(rows int) *VirtualList {
+	vl.ro.Lock()
+	vl.overscan = rows
+	vl.ro.Unlock()
+	return vl
+}
+
+// UseKey sets the function returning the key of a row, by default rows are
+// keyed by their index.
+func (vl *VirtualList) UseKey(key func(index int) string) *VirtualList {
+	vl.ro.Lock()
+	vl.key = key
+	vl.ro.Unlock()
+	return vl
+}
+
+// Measure records the measured height of a row, replacing its estimate.
+func (vl *VirtualList) Measure(index, height int) {
+	vl.ro.Lock()
+	vl.heights[index] = height
+	vl.ro.Unlock()
+
+	vl.Send(true)
+}
+
+// SetCount sets the number of rows of the list.
+func (vl *VirtualList) SetCount(count int) {
+	vl.ro.Lock()
+	vl.count = count
+	vl.ro.Unlock()
+
+	vl.Send(true)
+}
+
+// ScrollTo sets the scroll position of the list, sending only if the window
+// of rendered rows moved.
+func (vl *VirtualList) ScrollTo(top int) {
+	vl.ro.Lock()
+	start, end := vl.window()
+	vl.scrollTop = top
+	nstart, nend := vl.window()
+	vl.ro.Unlock()
+
+	if start != nstart || end != nend {
+		vl.Send(true)
+	}
+}
+
+// Window returns the index of the first row rendered and the index after the
+// last one.
+func (vl *VirtualList) Window() (start, end int) {
+	vl.ro.RLock()
+	defer vl.ro.RUnlock()
+	return vl.window()
+}
+
+// window returns the rendered rows. The list's lock must be held.
+func (vl *VirtualList) window() (start, end int) {
+	start = vl.indexAt(vl.scrollTop) - vl.overscan
+	end = vl.indexAt(vl.scrollTop+vl.height) + 1 + vl.overscan
+
+	if start < 0 {
+		start = 0
+	}
+
+	if end > vl.count {
+		end = vl.count
+	}
+
+	if start > end {
+		start = end
+	}
+
+	return start, end
+}
+
+// heightOf returns the measured or estimated height of the row. The list's
+// lock must be held.
+func (vl *VirtualList) heightOf(index int) int {
+	if h, ok := vl.heights[index]; ok {
+		return h
+	}
+	return vl.rowHeight
+}
+
+// offsetOf returns the offset in pixels of the top of the row. The list's
+// lock must be held.
+func (vl *VirtualList) offsetOf(index int) int {
+	if len(vl.heights) == 0 {
+		return index * vl.rowHeight
+	}
+
+	var offset int
+	for i := 0; i < index; i++ {
+		offset += vl.heightOf(i)
+	}
+	return offset
+}
+
+// indexAt returns the index of the row at the offset in pixels. The list's
+// lock must be held.
+func (vl *VirtualList) indexAt(offset int) int {
+	if len(vl.heights) == 0 {
+		return offset / vl.rowHeight
+	}
+
+	var top int
+	for i := 0; i < vl.count; i++ {
+		top += vl.heightOf(i)
+		if top > offset {
+			return i
+		}
+	}
+	return vl.count
+}
+
+// Render renders the rows within the window between the spacers, within a
+// scrolling container of the list's height.
+func (vl *VirtualList) Render(m ...string) trees.Markup {
+	vl.ro.RLock()
+	start, end := vl.window()
+	top := vl.offsetOf(start)
+	bottom := vl.offsetOf(vl.count) - vl.offsetOf(end)
+	height, key, row, id := vl.height, vl.key, vl.row, vl.id
+	vl.ro.RUnlock()
+
+	selector := fmt.Sprintf("[virtual-list='%s']", id)
+
+	list := elems.Div(
+		trees.NewAttr("virtual-list", id),
+		styles.Height(styles.Px(height)),
+		trees.NewStyle("overflow-y", "auto"),
+		events.Scroll(func(ev domevents.Event, _ trees.Markup) {
+			vl.ScrollTo(ev.Target().Get("scrollTop").Int())
+		}, selector),
+	)
+
+	list.AddChild(elems.Div(attrs.Key("virtual-top"), styles.Height(styles.Px(top))))
+
+	for i := start; i < end; i++ {
+		rm, ok := row(i).(*trees.Element)
+		if !ok || rm == nil {
+			continue
+		}
+
+		attrs.Key(key(i)).Apply(rm)
+		list.AddChild(rm)
+	}
+
+	list.AddChild(elems.Div(attrs.Key("virtual-bottom"), styles.Height(styles.Px(bottom))))
+
+	return list
+}
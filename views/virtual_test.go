@@ -0,0 +1,51 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+)
+
+func TestVirtualList(t *testing.T) {
+	list := NewVirtualList(50000, 20, 100, func(index int) trees.Markup {
+		return elems.Div(elems.Text(fmt.Sprintf("log %d", index)))
+	}).UseOverscan(2)
+
+	view := NewView(list)
+
+	if start, end := list.Window(); start != 0 || end != 8 {
+		fatalFailed(t, "Expected window of rows [0, 8) but got [%d, %d)", start, end)
+	}
+
+	out := string(view.RenderHTML())
+	if !strings.Contains(out, "log 7") || strings.Contains(out, "log 8") {
+		fatalFailed(t, "Expected only the visible rows with overscan to render")
+	}
+
+	logPassed(t, "Successfully rendered only the visible window")
+
+	first := view.liveMarkup.Children()[6]
+
+	list.ScrollTo(100)
+
+	if start, end := list.Window(); start != 3 || end != 13 {
+		fatalFailed(t, "Expected window of rows [3, 13) but got [%d, %d)", start, end)
+	}
+
+	view.Render()
+
+	spacer := view.liveMarkup.Children()[0]
+	if !trees.StyleContains(spacer, "height", "60px") {
+		fatalFailed(t, "Expected top spacer to cover the rows above the window")
+	}
+
+	moved := view.liveMarkup.Children()[3]
+	if moved.UID() != first.UID() {
+		fatalFailed(t, "Expected row 5 to keep its uid across scrolls")
+	}
+
+	logPassed(t, "Successfully kept keyed rows stable across scrolls")
+}
@@ -0,0 +1,160 @@
+package views
+
+import (
+	"github.com/influx6/haiku/base"
+	"github.com/influx6/haiku/trees"
+)
+
+// HideAttrView provides a ViewStates hiding the view's markup with the hidden
+// attribute.
+type HideAttrView struct{}
+
+// Render marks the given markup with the hidden attribute.
+func (h *HideAttrView) Render(m trees.Markup) {
+	if _, err := trees.GetAttr(m, "hidden"); err == nil {
+		return
+	}
+
+	if em, ok := m.(*trees.Element); ok {
+		trees.NewAttr("hidden", "").Apply(em)
+	}
+}
+
+// HideClassView provides a ViewStates hiding the view's markup with a css
+// class, the class defaults to `hidden`.
+type HideClassView struct {
+	Class string
+}
+
+// Render adds the class to the given markup.
+func (h *HideClassView) Render(m trees.Markup) {
+	class := h.Class
+	if class == "" {
+		class = "hidden"
+	}
+
+	addClass(m, class)
+}
+
+// UnmountView provides a ViewStates unmounting the view's markup while its
+// hidden, only the view's empty root is rendered and the events of its markup
+// are released till its shown again.
+type UnmountView struct{}
+
+// Render empties the given markup of its children and events.
+func (u *UnmountView) Render(m trees.Markup) {
+	m.Empty()
+
+	if em, ok := m.(*trees.Element); ok {
+		em.EmptyEvents()
+	}
+}
+
+// Begin releases the events of the view's markup as its unmounted.
+func (u *UnmountView) Begin(v *View) {
+	v.releaseEvents()
+	v.Send(true)
+}
+
+// ViewOption defines a function configuring a view as its added to Pages.
+type ViewOption func(Views)
+
+// HideWith returns a ViewOption hiding the view with the ViewStates while its
+// address is inactive.
+func HideWith(s ViewStates) ViewOption {
+	return func(v Views) {
+		v.UseHideState(s)
+	}
+}
+
+// TransitionWith returns a ViewOption transitioning the view in and out, once
+// left the view is hidden by the state of any HideWith given before it.
+func TransitionWith(t *Transition) ViewOption {
+	return func(v Views) {
+		v.UseTransition(t)
+	}
+}
+
+// UseShowState sets the ViewStates applied to the view's markup while its
+// shown, replacing the active state if the view is shown.
+func (v *View) UseShowState(s ViewStates) {
+	if s == nil {
+		s = &ShowView{}
+	}
+
+	if v.activeState != nil && v.activeState == v.ShowState {
+		v.activeState = s
+	}
+
+	v.ShowState = s
+}
+
+// UseHideState sets the ViewStates applied to the view's markup while its
+// hidden, replacing the active state if the view is hidden.
+func (v *View) UseHideState(s ViewStates) {
+	if s == nil {
+		s = &HideView{}
+	}
+
+	if v.hidden() {
+		v.activeState = s
+	}
+
+	v.HideState = s
+}
+
+// releaseEvents removes the events of the view's live markup from its events
+// manager and the managers attached to it, unbinding them from the dom.
+func (v *View) releaseEvents() {
+	if v.liveMarkup == nil {
+		return
+	}
+
+	events := make(map[string]bool)
+	markupEvents(v.liveMarkup, events)
+
+	releaseEvents(v.events, events, make(map[base.EventManagers]bool))
+}
+
+// markupEvents adds the type and target of the events of the markup and its
+// children into the set.
+func markupEvents(m trees.Markup, events map[string]bool) {
+	for _, ev := range m.Events() {
+		events[ev.Meta.EventType+"#"+ev.Meta.EventTarget] = true
+	}
+
+	for _, ch := range m.Children() {
+		markupEvents(ch, events)
+	}
+}
+
+// releaseEvents removes the events in the set from the manager and the
+// managers attached to it, skipping managers already seen as managers can end
+// up attached to themselves.
+func releaseEvents(em base.EventManagers, events map[string]bool, seen map[base.EventManagers]bool) {
+	if seen[em] {
+		return
+	}
+	seen[em] = true
+
+	var ids []string
+
+	em.EachEvent(func(es base.EventSubs) {
+		if events[es.Type()+"#"+es.Target()] {
+			ids = append(ids, base.GetEventID(es))
+		}
+	})
+
+	for _, id := range ids {
+		em.RemoveEvent(id)
+	}
+
+	var subs []base.EventManagers
+	em.EachManager(func(sub base.EventManagers) {
+		subs = append(subs, sub)
+	})
+
+	for _, sub := range subs {
+		releaseEvents(sub, events, seen)
+	}
+}
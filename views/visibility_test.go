@@ -0,0 +1,81 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influx6/faux/domevents"
+	"github.com/influx6/haiku/base"
+	"github.com/influx6/haiku/trees"
+	"github.com/influx6/haiku/trees/elems"
+	"github.com/influx6/haiku/trees/events"
+)
+
+type clicker string
+
+func (c clicker) Render(m ...string) trees.Markup {
+	return elems.Button(
+		elems.Text(string(c)),
+		events.Click(func(ev domevents.Event, _ trees.Markup) {}, ""),
+	)
+}
+
+func eventCount(v *View) int {
+	var total int
+	v.Events().EachEvent(func(base.EventSubs) {
+		total++
+	})
+	return total
+}
+
+func TestPagesVisibility(t *testing.T) {
+	pages := HistoryPage(NewMemoryHistory("/"), nil)
+
+	home := NewView(item("Home"))
+	about := NewView(item("About"))
+	shop := NewView(clicker("Shop"))
+
+	pages.AddView("home", home, HideWith(&HideAttrView{}))
+	pages.AddView("about", about, HideWith(&HideClassView{Class: "gone"}))
+	pages.AddView("shop", shop, HideWith(&UnmountView{}))
+
+	if out := string(home.RenderHTML()); !strings.Contains(out, "hidden") {
+		fatalFailed(t, "Expected view to start hidden till its address is active but got %q", out)
+	}
+
+	if err := pages.All(".home"); err != nil {
+		fatalFailed(t, "Unable to activate home: %s", err)
+	}
+
+	if out := string(home.RenderHTML()); strings.Contains(out, "hidden") {
+		fatalFailed(t, "Expected active view to be shown but got %q", out)
+	}
+
+	if out := string(about.RenderHTML()); !strings.Contains(out, `class="gone"`) {
+		fatalFailed(t, "Expected inactive view to have hidden class but got %q", out)
+	}
+
+	logPassed(t, "Successfully hid inactive views with their strategies")
+
+	pages.All(".shop")
+
+	if out := string(shop.RenderHTML()); !strings.Contains(out, "Shop") || eventCount(shop) == 0 {
+		fatalFailed(t, "Expected active view to render with its events but got %q", out)
+	}
+
+	if out := string(home.RenderHTML()); !strings.Contains(out, "hidden") {
+		fatalFailed(t, "Expected previously active view to be hidden but got %q", out)
+	}
+
+	pages.All(".about")
+
+	if out := string(shop.RenderHTML()); strings.Contains(out, "Shop") {
+		fatalFailed(t, "Expected unmounted view to render nothing but got %q", out)
+	}
+
+	if total := eventCount(shop); total != 0 {
+		fatalFailed(t, "Expected unmounted view to release its events but has %d", total)
+	}
+
+	logPassed(t, "Successfully unmounted inactive view")
+}